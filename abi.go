@@ -0,0 +1,155 @@
+package leapapi
+
+import jsoniter "github.com/json-iterator/go"
+
+// ABI type alias definition.
+type ABIType struct {
+	NewTypeName string `json:"new_type_name"`
+	Type        string `json:"type"`
+}
+
+// ABI struct field definition.
+type ABIField struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// ABI struct definition.
+type ABIStruct struct {
+	Name   string     `json:"name"`
+	Base   string     `json:"base"`
+	Fields []ABIField `json:"fields"`
+}
+
+// ABI action definition.
+type ABIAction struct {
+	Name              string `json:"name"`
+	Type              string `json:"type"`
+	RicardianContract string `json:"ricardian_contract"`
+}
+
+// ABI table definition.
+type ABITable struct {
+	Name      string   `json:"name"`
+	IndexType string   `json:"index_type"`
+	KeyNames  []string `json:"key_names"`
+	KeyTypes  []string `json:"key_types"`
+	Type      string   `json:"type"`
+}
+
+// ABI ricardian clause.
+type ABIClause struct {
+	ID   string `json:"id"`
+	Body string `json:"body"`
+}
+
+// ABI error message.
+type ABIErrorMessage struct {
+	Code    Uint64 `json:"error_code"`
+	Message string `json:"error_msg"`
+}
+
+// ABI variant definition.
+type ABIVariant struct {
+	Name  string   `json:"name"`
+	Types []string `json:"types"`
+}
+
+// ABI action result definition.
+type ABIActionResult struct {
+	Name       string `json:"name"`
+	ResultType string `json:"result_type"`
+}
+
+// ABI key value table index.
+type ABIKVIndex struct {
+	Name string `json:"name,omitempty"`
+	Type string `json:"type"`
+}
+
+// ABI key value table definition.
+type ABIKVTable struct {
+	Type             string                `json:"type"`
+	PrimaryIndex     ABIKVIndex            `json:"primary_index"`
+	SecondaryIndices map[string]ABIKVIndex `json:"secondary_indices,omitempty"`
+}
+
+// ABI definition
+type ABI struct {
+	Version          string                `json:"version"`
+	Types            []ABIType             `json:"types"`
+	Structs          []ABIStruct           `json:"structs"`
+	Actions          []ABIAction           `json:"actions"`
+	Tables           []ABITable            `json:"tables"`
+	RicardianClauses []ABIClause           `json:"ricardian_clauses"`
+	ErrorMessages    []ABIErrorMessage     `json:"error_messages"`
+	Extensions       []Extension           `json:"abi_extensions"`
+	Variants         []ABIVariant          `json:"variants,omitempty"`
+	ActionResults    []ABIActionResult     `json:"action_results,omitempty"`
+	KVTables         map[string]ABIKVTable `json:"kv_tables,omitempty"`
+}
+
+// get_abi format
+type AccountABI struct {
+	AccountName Name `json:"account_name"`
+	ABI         *ABI `json:"abi,omitempty"`
+}
+
+// get_raw_abi format
+type RawABI struct {
+	AccountName Name   `json:"account_name"`
+	CodeHash    string `json:"code_hash"`
+	ABIHash     string `json:"abi_hash"`
+	ABI         []byte `json:"abi"`
+}
+
+// get_code format
+type Code struct {
+	AccountName Name   `json:"account_name"`
+	CodeHash    string `json:"code_hash"`
+	WAST        string `json:"wast"`
+	WASM        string `json:"wasm"`
+	ABI         *ABI   `json:"abi,omitempty"`
+}
+
+// get_code_hash format
+type CodeHash struct {
+	AccountName Name   `json:"account_name"`
+	CodeHash    string `json:"code_hash"`
+}
+
+// get_raw_code_and_abi format
+type RawCodeAndABI struct {
+	AccountName Name   `json:"account_name"`
+	WASM        []byte `json:"wasm"`
+	ABI         []byte `json:"abi"`
+}
+
+// abi_json_to_bin request format
+type ABIJSONToBinRequest struct {
+	Code   string      `json:"code"`
+	Action string      `json:"action"`
+	Args   interface{} `json:"args"`
+}
+
+// abi_json_to_bin response format
+type ABIJSONToBinResponse struct {
+	Binargs HexBytes `json:"binargs"`
+}
+
+// abi_bin_to_json request format
+type ABIBinToJSONRequest struct {
+	Code    string   `json:"code"`
+	Action  string   `json:"action"`
+	Binargs HexBytes `json:"binargs"`
+}
+
+// abi_bin_to_json response format
+type ABIBinToJSONResponse struct {
+	Args jsoniter.RawMessage `json:"args"`
+}
+
+// Decode args into v
+func (r ABIBinToJSONResponse) Decode(v interface{}) error {
+	return json.Unmarshal(r.Args, v)
+}
@@ -0,0 +1,1208 @@
+package leapapi
+
+import (
+	"encoding/hex"
+	stdjson "encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Max nesting of types when encoding/decoding (same as nodeos).
+const abiMaxDepth = 32
+
+// Json config used to convert values into generic json values before encoding.
+// Numbers are kept as strings so 64 and 128 bit integers do not lose precision.
+var abiJSON = jsoniter.Config{UseNumber: true}.Froze()
+
+const (
+	timePointFormat    = "2006-01-02T15:04:05.000"
+	timePointSecFormat = "2006-01-02T15:04:05"
+
+	// Block timestamps are counted in 500ms slots from year 2000.
+	blockTimestampEpochMs    = 946684800000
+	blockTimestampIntervalMs = 500
+)
+
+type abiBuiltin struct {
+	encode func(e *encoder, v interface{}) error
+	decode func(d *decoder, s *jsoniter.Stream) error
+}
+
+var abiBuiltins map[string]abiBuiltin
+
+func init() {
+	abiBuiltins = map[string]abiBuiltin{
+		"bool":                 {encodeABIBool, decodeABIBool},
+		"int8":                 {encodeABIInt(8), decodeABIInt(8)},
+		"uint8":                {encodeABIUint(8), decodeABIUint(8)},
+		"int16":                {encodeABIInt(16), decodeABIInt(16)},
+		"uint16":               {encodeABIUint(16), decodeABIUint(16)},
+		"int32":                {encodeABIInt(32), decodeABIInt(32)},
+		"uint32":               {encodeABIUint(32), decodeABIUint(32)},
+		"int64":                {encodeABIInt(64), decodeABIInt(64)},
+		"uint64":               {encodeABIUint(64), decodeABIUint(64)},
+		"int128":               {encodeABIInt128(true), decodeABIInt128(true)},
+		"uint128":              {encodeABIInt128(false), decodeABIInt128(false)},
+		"varint32":             {encodeABIVarInt32, decodeABIVarInt32},
+		"varuint32":            {encodeABIVarUint32, decodeABIVarUint32},
+		"float32":              {encodeABIFloat(32), decodeABIFloat(32)},
+		"float64":              {encodeABIFloat(64), decodeABIFloat(64)},
+		"float128":             {encodeABIFloat128, decodeABIFloat128},
+		"time_point":           {encodeABITimePoint, decodeABITimePoint},
+		"time_point_sec":       {encodeABITimePointSec, decodeABITimePointSec},
+		"block_timestamp_type": {encodeABIBlockTimestamp, decodeABIBlockTimestamp},
+		"name":                 {encodeABIName, decodeABIName},
+		"bytes":                {encodeABIBytes, decodeABIBytes},
+		"string":               {encodeABIString, decodeABIString},
+		"checksum160":          {encodeABIChecksum(20), decodeABIChecksum(20)},
+		"checksum256":          {encodeABIChecksum(32), decodeABIChecksum(32)},
+		"checksum512":          {encodeABIChecksum(64), decodeABIChecksum(64)},
+		"public_key":           {encodeABIPublicKey, decodeABIPublicKey},
+		"signature":            {encodeABISignature, decodeABISignature},
+		"symbol":               {encodeABISymbol, decodeABISymbol},
+		"symbol_code":          {encodeABISymbolCode, decodeABISymbolCode},
+		"asset":                {encodeABIAsset, decodeABIAsset},
+		"extended_asset":       {encodeABIExtendedAsset, decodeABIExtendedAsset},
+	}
+}
+
+// ActionType returns the type of an action.
+func (a *ABI) ActionType(name string) (string, bool) {
+	for _, act := range a.Actions {
+		if act.Name == name {
+			return act.Type, true
+		}
+	}
+	return "", false
+}
+
+// TableType returns the row type of a table.
+func (a *ABI) TableType(name string) (string, bool) {
+	for _, t := range a.Tables {
+		if t.Name == name {
+			return t.Type, true
+		}
+	}
+	return "", false
+}
+
+// EncodeType encodes v as typeName in binary format.
+//
+// v can be any value that encodes to json in the same format as
+// nodeos uses for the type (for example a struct with json tags
+// or a map[string]interface{}). jsoniter.RawMessage is encoded as is.
+func (a *ABI) EncodeType(typeName string, v interface{}) ([]byte, error) {
+	generic, err := abiGenericValue(v)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &encoder{}
+	if err = a.encode(e, typeName, generic, 0); err != nil {
+		return nil, fmt.Errorf("abi: %v", err)
+	}
+	return e.Bytes(), nil
+}
+
+// DecodeType decodes binary data of typeName and returns it as json.
+func (a *ABI) DecodeType(typeName string, data []byte) ([]byte, error) {
+	d := newDecoder(data)
+	s := json.BorrowStream(nil)
+	defer json.ReturnStream(s)
+
+	if err := a.decode(d, s, typeName, 0); err != nil {
+		return nil, fmt.Errorf("abi: %v", err)
+	}
+
+	if s.Error != nil {
+		return nil, fmt.Errorf("abi: %v", s.Error)
+	}
+
+	if d.remaining() > 0 {
+		return nil, fmt.Errorf("abi: %d bytes left after decoding %s", d.remaining(), typeName)
+	}
+
+	return append([]byte{}, s.Buffer()...), nil
+}
+
+// EncodeAction encodes action data.
+func (a *ABI) EncodeAction(action string, v interface{}) ([]byte, error) {
+	t, ok := a.ActionType(action)
+	if !ok {
+		return nil, fmt.Errorf("abi: action %q not found", action)
+	}
+	return a.EncodeType(t, v)
+}
+
+// DecodeAction decodes action data and returns it as json.
+func (a *ABI) DecodeAction(action string, data []byte) ([]byte, error) {
+	t, ok := a.ActionType(action)
+	if !ok {
+		return nil, fmt.Errorf("abi: action %q not found", action)
+	}
+	return a.DecodeType(t, data)
+}
+
+// EncodeTableRow encodes a table row.
+func (a *ABI) EncodeTableRow(table string, v interface{}) ([]byte, error) {
+	t, ok := a.TableType(table)
+	if !ok {
+		return nil, fmt.Errorf("abi: table %q not found", table)
+	}
+	return a.EncodeType(t, v)
+}
+
+// DecodeTableRow decodes a table row and returns it as json.
+func (a *ABI) DecodeTableRow(table string, data []byte) ([]byte, error) {
+	t, ok := a.TableType(table)
+	if !ok {
+		return nil, fmt.Errorf("abi: table %q not found", table)
+	}
+	return a.DecodeType(t, data)
+}
+
+func abiGenericValue(v interface{}) (interface{}, error) {
+	var err error
+	var b []byte
+
+	if raw, ok := v.(jsoniter.RawMessage); ok {
+		b = raw
+	} else if b, err = json.Marshal(v); err != nil {
+		return nil, err
+	}
+
+	var out interface{}
+	err = abiJSON.Unmarshal(b, &out)
+	return out, err
+}
+
+// Follow type aliases.
+func (a *ABI) resolveType(t string) string {
+	for i := 0; i < abiMaxDepth; i++ {
+		found := false
+		for _, alias := range a.Types {
+			if alias.NewTypeName == t {
+				t, found = alias.Type, true
+				break
+			}
+		}
+
+		if !found {
+			break
+		}
+	}
+	return t
+}
+
+func (a *ABI) findStruct(name string) *ABIStruct {
+	for i := range a.Structs {
+		if a.Structs[i].Name == name {
+			return &a.Structs[i]
+		}
+	}
+	return nil
+}
+
+func (a *ABI) findVariant(name string) *ABIVariant {
+	for i := range a.Variants {
+		if a.Variants[i].Name == name {
+			return &a.Variants[i]
+		}
+	}
+	return nil
+}
+
+func (a *ABI) encode(e *encoder, t string, v interface{}, depth int) error {
+	if depth > abiMaxDepth {
+		return fmt.Errorf("recursion depth exceeded")
+	}
+
+	t = a.resolveType(t)
+	switch {
+	case strings.HasSuffix(t, "$"):
+		return a.encode(e, t[:len(t)-1], v, depth+1)
+	case strings.HasSuffix(t, "?"):
+		if v == nil {
+			e.writeUint8(0)
+			return nil
+		}
+		e.writeUint8(1)
+		return a.encode(e, t[:len(t)-1], v, depth+1)
+	case strings.HasSuffix(t, "[]"):
+		arr, ok := v.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected array, got %T", t, v)
+		}
+
+		e.writeVarUint32(uint32(len(arr)))
+		for _, item := range arr {
+			if err := a.encode(e, t[:len(t)-2], item, depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if b, ok := abiBuiltins[t]; ok {
+		if err := b.encode(e, v); err != nil {
+			return fmt.Errorf("%s: %v", t, err)
+		}
+		return nil
+	}
+
+	if st := a.findStruct(t); st != nil {
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected object, got %T", t, v)
+		}
+		_, err := a.encodeStructFields(e, st, obj, false, depth+1)
+		return err
+	}
+
+	if vr := a.findVariant(t); vr != nil {
+		return a.encodeVariant(e, vr, v, depth+1)
+	}
+
+	return fmt.Errorf("unknown type %q", t)
+}
+
+// Returns true if a missing binary extension field was found.
+func (a *ABI) encodeStructFields(e *encoder, st *ABIStruct, obj map[string]interface{}, extMissing bool, depth int) (bool, error) {
+	if depth > abiMaxDepth {
+		return extMissing, fmt.Errorf("recursion depth exceeded")
+	}
+
+	if len(st.Base) > 0 {
+		base := a.findStruct(a.resolveType(st.Base))
+		if base == nil {
+			return extMissing, fmt.Errorf("%s: unknown base %q", st.Name, st.Base)
+		}
+
+		var err error
+		if extMissing, err = a.encodeStructFields(e, base, obj, extMissing, depth+1); err != nil {
+			return extMissing, err
+		}
+	}
+
+	for _, f := range st.Fields {
+		v, ok := obj[f.Name]
+		if !ok {
+			if strings.HasSuffix(f.Type, "$") {
+				extMissing = true
+				continue
+			}
+			return extMissing, fmt.Errorf("%s: missing field %q", st.Name, f.Name)
+		}
+
+		if extMissing {
+			return extMissing, fmt.Errorf("%s: unexpected field %q after missing binary extension", st.Name, f.Name)
+		}
+
+		if err := a.encode(e, f.Type, v, depth+1); err != nil {
+			return extMissing, fmt.Errorf("%s.%s: %v", st.Name, f.Name, err)
+		}
+	}
+	return extMissing, nil
+}
+
+// Variants are encoded as ["type", value] in json.
+func (a *ABI) encodeVariant(e *encoder, vr *ABIVariant, v interface{}, depth int) error {
+	arr, ok := v.([]interface{})
+	if !ok || len(arr) != 2 {
+		return fmt.Errorf("%s: expected [type, value]", vr.Name)
+	}
+
+	name, ok := arr[0].(string)
+	if !ok {
+		return fmt.Errorf("%s: expected type name, got %T", vr.Name, arr[0])
+	}
+
+	for i, t := range vr.Types {
+		if t == name {
+			e.writeVarUint32(uint32(i))
+			return a.encode(e, t, arr[1], depth+1)
+		}
+	}
+	return fmt.Errorf("%s: type %q is not part of variant", vr.Name, name)
+}
+
+func (a *ABI) decode(d *decoder, s *jsoniter.Stream, t string, depth int) error {
+	if depth > abiMaxDepth {
+		return fmt.Errorf("recursion depth exceeded")
+	}
+
+	t = a.resolveType(t)
+	switch {
+	case strings.HasSuffix(t, "$"):
+		return a.decode(d, s, t[:len(t)-1], depth+1)
+	case strings.HasSuffix(t, "?"):
+		set, err := d.readBool()
+		if err != nil {
+			return fmt.Errorf("%s: %v", t, err)
+		}
+
+		if !set {
+			s.WriteNil()
+			return nil
+		}
+		return a.decode(d, s, t[:len(t)-1], depth+1)
+	case strings.HasSuffix(t, "[]"):
+		n, err := d.readVarUint32()
+		if err != nil {
+			return fmt.Errorf("%s: %v", t, err)
+		}
+
+		// Reject counts larger than the data left, corrupt
+		// data would otherwise loop (almost) forever.
+		if int64(n) > int64(d.remaining()) {
+			return fmt.Errorf("%s: %v", t, io.ErrUnexpectedEOF)
+		}
+
+		s.WriteArrayStart()
+		for i := uint32(0); i < n; i++ {
+			if i > 0 {
+				s.WriteMore()
+			}
+
+			if err := a.decode(d, s, t[:len(t)-2], depth+1); err != nil {
+				return err
+			}
+		}
+		s.WriteArrayEnd()
+		return nil
+	}
+
+	if b, ok := abiBuiltins[t]; ok {
+		if err := b.decode(d, s); err != nil {
+			return fmt.Errorf("%s: %v", t, err)
+		}
+		return nil
+	}
+
+	if st := a.findStruct(t); st != nil {
+		first := true
+		s.WriteObjectStart()
+		if _, err := a.decodeStructFields(d, s, st, &first, depth+1); err != nil {
+			return err
+		}
+		s.WriteObjectEnd()
+		return nil
+	}
+
+	if vr := a.findVariant(t); vr != nil {
+		i, err := d.readVarUint32()
+		if err != nil {
+			return fmt.Errorf("%s: %v", t, err)
+		}
+
+		if int(i) >= len(vr.Types) {
+			return fmt.Errorf("%s: invalid variant index %d", t, i)
+		}
+
+		s.WriteArrayStart()
+		s.WriteString(vr.Types[i])
+		s.WriteMore()
+		if err = a.decode(d, s, vr.Types[i], depth+1); err != nil {
+			return err
+		}
+		s.WriteArrayEnd()
+		return nil
+	}
+
+	return fmt.Errorf("unknown type %q", t)
+}
+
+// Returns true if decoding stopped at a binary extension field (no more data).
+func (a *ABI) decodeStructFields(d *decoder, s *jsoniter.Stream, st *ABIStruct, first *bool, depth int) (bool, error) {
+	if depth > abiMaxDepth {
+		return false, fmt.Errorf("recursion depth exceeded")
+	}
+
+	if len(st.Base) > 0 {
+		base := a.findStruct(a.resolveType(st.Base))
+		if base == nil {
+			return false, fmt.Errorf("%s: unknown base %q", st.Name, st.Base)
+		}
+
+		stop, err := a.decodeStructFields(d, s, base, first, depth+1)
+		if err != nil || stop {
+			return stop, err
+		}
+	}
+
+	for _, f := range st.Fields {
+		if strings.HasSuffix(f.Type, "$") && d.remaining() < 1 {
+			return true, nil
+		}
+
+		if !*first {
+			s.WriteMore()
+		}
+		*first = false
+
+		s.WriteObjectField(f.Name)
+		if err := a.decode(d, s, f.Type, depth+1); err != nil {
+			return false, fmt.Errorf("%s.%s: %v", st.Name, f.Name, err)
+		}
+	}
+	return false, nil
+}
+
+//  Builtin types
+// ---------------------------------------------------------
+
+func abiNumberString(v interface{}) (string, error) {
+	switch t := v.(type) {
+	case stdjson.Number:
+		return string(t), nil
+	case string:
+		return t, nil
+	}
+	return "", fmt.Errorf("expected number, got %T", v)
+}
+
+func abiString(v interface{}) (string, error) {
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("expected string, got %T", v)
+	}
+	return s, nil
+}
+
+func encodeABIBool(e *encoder, v interface{}) error {
+	b, ok := v.(bool)
+	if !ok {
+		return fmt.Errorf("expected bool, got %T", v)
+	}
+	e.writeBool(b)
+	return nil
+}
+
+func decodeABIBool(d *decoder, s *jsoniter.Stream) error {
+	v, err := d.readBool()
+	if err == nil {
+		s.WriteBool(v)
+	}
+	return err
+}
+
+func writeABIUint(e *encoder, v uint64, bits int) {
+	switch bits {
+	case 8:
+		e.writeUint8(uint8(v))
+	case 16:
+		e.writeUint16(uint16(v))
+	case 32:
+		e.writeUint32(uint32(v))
+	default:
+		e.writeUint64(v)
+	}
+}
+
+func readABIUint(d *decoder, bits int) (uint64, error) {
+	switch bits {
+	case 8:
+		v, err := d.readUint8()
+		return uint64(v), err
+	case 16:
+		v, err := d.readUint16()
+		return uint64(v), err
+	case 32:
+		v, err := d.readUint32()
+		return uint64(v), err
+	}
+	return d.readUint64()
+}
+
+func encodeABIInt(bits int) func(e *encoder, v interface{}) error {
+	return func(e *encoder, v interface{}) error {
+		str, err := abiNumberString(v)
+		if err != nil {
+			return err
+		}
+
+		n, err := strconv.ParseInt(str, 10, bits)
+		if err != nil {
+			return err
+		}
+		writeABIUint(e, uint64(n), bits)
+		return nil
+	}
+}
+
+func decodeABIInt(bits int) func(d *decoder, s *jsoniter.Stream) error {
+	return func(d *decoder, s *jsoniter.Stream) error {
+		v, err := readABIUint(d, bits)
+		if err != nil {
+			return err
+		}
+
+		// Sign extend
+		shift := uint(64 - bits)
+		n := int64(v<<shift) >> shift
+
+		// nodeos quotes integers that do not fit in 32 bits.
+		if n > math.MaxUint32 || n < -math.MaxUint32 {
+			s.WriteString(strconv.FormatInt(n, 10))
+		} else {
+			s.WriteInt64(n)
+		}
+		return nil
+	}
+}
+
+func encodeABIUint(bits int) func(e *encoder, v interface{}) error {
+	return func(e *encoder, v interface{}) error {
+		str, err := abiNumberString(v)
+		if err != nil {
+			return err
+		}
+
+		n, err := strconv.ParseUint(str, 10, bits)
+		if err != nil {
+			return err
+		}
+		writeABIUint(e, n, bits)
+		return nil
+	}
+}
+
+func decodeABIUint(bits int) func(d *decoder, s *jsoniter.Stream) error {
+	return func(d *decoder, s *jsoniter.Stream) error {
+		v, err := readABIUint(d, bits)
+		if err != nil {
+			return err
+		}
+
+		if v > math.MaxUint32 {
+			s.WriteString(strconv.FormatUint(v, 10))
+		} else {
+			s.WriteUint64(v)
+		}
+		return nil
+	}
+}
+
+var (
+	abiUint128Max = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+	abiInt128Min  = new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 127))
+	abiInt128Max  = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 127), big.NewInt(1))
+	abiTwoPow128  = new(big.Int).Lsh(big.NewInt(1), 128)
+)
+
+func encodeABIInt128(signed bool) func(e *encoder, v interface{}) error {
+	return func(e *encoder, v interface{}) error {
+		str, err := abiNumberString(v)
+		if err != nil {
+			return err
+		}
+
+		n, ok := new(big.Int).SetString(str, 0)
+		if !ok {
+			return fmt.Errorf("invalid number %q", str)
+		}
+
+		min, max := big.NewInt(0), abiUint128Max
+		if signed {
+			min, max = abiInt128Min, abiInt128Max
+		}
+
+		if n.Cmp(min) < 0 || n.Cmp(max) > 0 {
+			return fmt.Errorf("%s is out of range", str)
+		}
+
+		// Two's complement
+		if n.Sign() < 0 {
+			n.Add(n, abiTwoPow128)
+		}
+
+		var b [16]byte
+		n.FillBytes(b[:])
+		e.writeBytes(reverseBytes(b[:]))
+		return nil
+	}
+}
+
+func decodeABIInt128(signed bool) func(d *decoder, s *jsoniter.Stream) error {
+	return func(d *decoder, s *jsoniter.Stream) error {
+		b, err := d.readBytes(16)
+		if err != nil {
+			return err
+		}
+
+		n := new(big.Int).SetBytes(reverseBytes(b))
+		if signed && n.Cmp(abiInt128Max) > 0 {
+			n.Sub(n, abiTwoPow128)
+		}
+		s.WriteString(n.String())
+		return nil
+	}
+}
+
+func reverseBytes(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i := range b {
+		r[len(b)-1-i] = b[i]
+	}
+	return r
+}
+
+func encodeABIVarInt32(e *encoder, v interface{}) error {
+	str, err := abiNumberString(v)
+	if err != nil {
+		return err
+	}
+
+	n, err := strconv.ParseInt(str, 10, 32)
+	if err == nil {
+		e.writeVarInt32(int32(n))
+	}
+	return err
+}
+
+func decodeABIVarInt32(d *decoder, s *jsoniter.Stream) error {
+	v, err := d.readVarInt32()
+	if err == nil {
+		s.WriteInt32(v)
+	}
+	return err
+}
+
+func encodeABIVarUint32(e *encoder, v interface{}) error {
+	str, err := abiNumberString(v)
+	if err != nil {
+		return err
+	}
+
+	n, err := strconv.ParseUint(str, 10, 32)
+	if err == nil {
+		e.writeVarUint32(uint32(n))
+	}
+	return err
+}
+
+func decodeABIVarUint32(d *decoder, s *jsoniter.Stream) error {
+	v, err := d.readVarUint32()
+	if err == nil {
+		s.WriteUint32(v)
+	}
+	return err
+}
+
+func encodeABIFloat(bits int) func(e *encoder, v interface{}) error {
+	return func(e *encoder, v interface{}) error {
+		str, err := abiNumberString(v)
+		if err != nil {
+			return err
+		}
+
+		f, err := strconv.ParseFloat(str, bits)
+		if err != nil {
+			return err
+		}
+
+		if bits == 32 {
+			e.writeFloat32(float32(f))
+		} else {
+			e.writeFloat64(f)
+		}
+		return nil
+	}
+}
+
+func decodeABIFloat(bits int) func(d *decoder, s *jsoniter.Stream) error {
+	return func(d *decoder, s *jsoniter.Stream) error {
+		var v float64
+		if bits == 32 {
+			f, err := d.readFloat32()
+			if err != nil {
+				return err
+			}
+			v = float64(f)
+		} else {
+			f, err := d.readFloat64()
+			if err != nil {
+				return err
+			}
+			v = f
+		}
+
+		// nodeos outputs floats as strings (same format as VoteWeight).
+		s.WriteString(strconv.FormatFloat(v, 'f', 17, 64))
+		return nil
+	}
+}
+
+// float128 is encoded as "0x<hex of the 16 bytes>" in json.
+func encodeABIFloat128(e *encoder, v interface{}) error {
+	str, err := abiString(v)
+	if err != nil {
+		return err
+	}
+
+	b, err := hex.DecodeString(strings.TrimPrefix(str, "0x"))
+	if err != nil {
+		return err
+	}
+
+	if len(b) != 16 {
+		return fmt.Errorf("expected 16 bytes, got %d", len(b))
+	}
+	e.writeBytes(b)
+	return nil
+}
+
+func decodeABIFloat128(d *decoder, s *jsoniter.Stream) error {
+	b, err := d.readBytes(16)
+	if err == nil {
+		s.WriteString("0x" + hex.EncodeToString(b))
+	}
+	return err
+}
+
+func parseABITime(v interface{}) (time.Time, error) {
+	str, err := abiString(v)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	// time.Parse only handles 4 digit years, larger years (time_point::maximum
+	// is in year 294247) are parsed in a year with the same position in the
+	// 400 year gregorian cycle and moved forward.
+	if i := strings.IndexByte(str, '-'); i > 4 {
+		year, err := strconv.Atoi(str[:i])
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		base := 2000 + year%400
+		t, err := time.ParseInLocation(timePointSecFormat, strconv.Itoa(base)+str[i:], time.UTC)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return t.AddDate(year-base, 0, 0), nil
+	}
+
+	// Fractional seconds are optional when parsing.
+	return time.ParseInLocation(timePointSecFormat, str, time.UTC)
+}
+
+func encodeABITimePoint(e *encoder, v interface{}) error {
+	t, err := parseABITime(v)
+	if err == nil {
+		e.writeUint64(uint64(t.Unix()*1e6 + int64(t.Nanosecond()/1e3)))
+	}
+	return err
+}
+
+func decodeABITimePoint(d *decoder, s *jsoniter.Stream) error {
+	us, err := d.readUint64()
+	if err == nil {
+		t := time.Unix(int64(us/1e6), int64(us%1e6)*1000).UTC()
+		s.WriteString(t.Format(timePointFormat))
+	}
+	return err
+}
+
+func encodeABITimePointSec(e *encoder, v interface{}) error {
+	t, err := parseABITime(v)
+	if err == nil {
+		e.writeUint32(uint32(t.Unix()))
+	}
+	return err
+}
+
+func decodeABITimePointSec(d *decoder, s *jsoniter.Stream) error {
+	sec, err := d.readUint32()
+	if err == nil {
+		s.WriteString(time.Unix(int64(sec), 0).UTC().Format(timePointSecFormat))
+	}
+	return err
+}
+
+func encodeABIBlockTimestamp(e *encoder, v interface{}) error {
+	t, err := parseABITime(v)
+	if err == nil {
+		ms := t.Unix()*1e3 + int64(t.Nanosecond()/1e6)
+		e.writeUint32(uint32((ms - blockTimestampEpochMs) / blockTimestampIntervalMs))
+	}
+	return err
+}
+
+func decodeABIBlockTimestamp(d *decoder, s *jsoniter.Stream) error {
+	slot, err := d.readUint32()
+	if err == nil {
+		ms := int64(slot)*blockTimestampIntervalMs + blockTimestampEpochMs
+		t := time.Unix(ms/1e3, (ms%1e3)*1e6).UTC()
+		s.WriteString(t.Format(timePointFormat))
+	}
+	return err
+}
+
+func encodeABIName(e *encoder, v interface{}) error {
+	str, err := abiString(v)
+	if err != nil {
+		return err
+	}
+
+	n, err := nameToUint64(str)
+	if err == nil {
+		e.writeUint64(n)
+	}
+	return err
+}
+
+func decodeABIName(d *decoder, s *jsoniter.Stream) error {
+	v, err := d.readUint64()
+	if err == nil {
+		s.WriteString(uint64ToName(v))
+	}
+	return err
+}
+
+// bytes are hex encoded in json.
+func encodeABIBytes(e *encoder, v interface{}) error {
+	str, err := abiString(v)
+	if err != nil {
+		return err
+	}
+
+	b, err := hex.DecodeString(str)
+	if err == nil {
+		e.writeByteArray(b)
+	}
+	return err
+}
+
+func decodeABIBytes(d *decoder, s *jsoniter.Stream) error {
+	b, err := d.readByteArray()
+	if err == nil {
+		s.WriteString(hex.EncodeToString(b))
+	}
+	return err
+}
+
+func encodeABIString(e *encoder, v interface{}) error {
+	str, err := abiString(v)
+	if err == nil {
+		e.writeString(str)
+	}
+	return err
+}
+
+func decodeABIString(d *decoder, s *jsoniter.Stream) error {
+	str, err := d.readString()
+	if err == nil {
+		s.WriteString(str)
+	}
+	return err
+}
+
+func encodeABIChecksum(size int) func(e *encoder, v interface{}) error {
+	return func(e *encoder, v interface{}) error {
+		str, err := abiString(v)
+		if err != nil {
+			return err
+		}
+
+		b, err := hex.DecodeString(str)
+		if err != nil {
+			return err
+		}
+
+		if len(b) != size {
+			return fmt.Errorf("expected %d bytes, got %d", size, len(b))
+		}
+		e.writeBytes(b)
+		return nil
+	}
+}
+
+func decodeABIChecksum(size int) func(d *decoder, s *jsoniter.Stream) error {
+	return func(d *decoder, s *jsoniter.Stream) error {
+		b, err := d.readBytes(size)
+		if err == nil {
+			s.WriteString(hex.EncodeToString(b))
+		}
+		return err
+	}
+}
+
+func encodeABIPublicKey(e *encoder, v interface{}) error {
+	str, err := abiString(v)
+	if err != nil {
+		return err
+	}
+
+	t, data, err := decodePublicKeyString(str)
+	if err == nil {
+		e.writeVarUint32(uint32(t))
+		e.writeBytes(data)
+	}
+	return err
+}
+
+// Read the raw data of a public key of type t.
+func readPublicKeyData(d *decoder, t KeyType) ([]byte, error) {
+	start := d.pos
+	if _, err := d.readBytes(33); err != nil {
+		return nil, err
+	}
+
+	switch t {
+	case KeyTypeK1, KeyTypeR1:
+	case KeyTypeWA:
+		// user presence and rpid
+		if _, err := d.readUint8(); err != nil {
+			return nil, err
+		}
+
+		if _, err := d.readString(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown key type %d", t)
+	}
+	return d.data[start:d.pos], nil
+}
+
+func decodeABIPublicKey(d *decoder, s *jsoniter.Stream) error {
+	t, err := d.readVarUint32()
+	if err != nil {
+		return err
+	}
+
+	data, err := readPublicKeyData(d, KeyType(t))
+	if err == nil {
+		s.WriteString(encodePublicKeyString(KeyType(t), data))
+	}
+	return err
+}
+
+func encodeABISignature(e *encoder, v interface{}) error {
+	str, err := abiString(v)
+	if err != nil {
+		return err
+	}
+
+	t, data, err := decodeSignatureString(str)
+	if err == nil {
+		e.writeVarUint32(uint32(t))
+		e.writeBytes(data)
+	}
+	return err
+}
+
+// Read the raw data of a signature of type t.
+func readSignatureData(d *decoder, t KeyType) ([]byte, error) {
+	start := d.pos
+	if _, err := d.readBytes(65); err != nil {
+		return nil, err
+	}
+
+	switch t {
+	case KeyTypeK1, KeyTypeR1:
+	case KeyTypeWA:
+		// auth data and client json
+		if _, err := d.readByteArray(); err != nil {
+			return nil, err
+		}
+
+		if _, err := d.readString(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown signature type %d", t)
+	}
+	return d.data[start:d.pos], nil
+}
+
+func decodeABISignature(d *decoder, s *jsoniter.Stream) error {
+	t, err := d.readVarUint32()
+	if err != nil {
+		return err
+	}
+
+	data, err := readSignatureData(d, KeyType(t))
+	if err == nil {
+		s.WriteString(encodeSignatureString(KeyType(t), data))
+	}
+	return err
+}
+
+func symbolCodeToUint64(code string) (uint64, error) {
+	if !validSymbolCode(code) {
+		return 0, fmt.Errorf("invalid symbol code %q", code)
+	}
+
+	var v uint64
+	for i := len(code) - 1; i >= 0; i-- {
+		v = v<<8 | uint64(code[i])
+	}
+	return v, nil
+}
+
+func uint64ToSymbolCode(v uint64) (string, error) {
+	code := []byte{}
+	for ; v > 0; v >>= 8 {
+		code = append(code, byte(v&0xff))
+	}
+
+	if !validSymbolCode(string(code)) {
+		return "", fmt.Errorf("invalid symbol code %q", code)
+	}
+	return string(code), nil
+}
+
+func writeABISymbol(e *encoder, sym Symbol) error {
+	code, err := symbolCodeToUint64(sym.Code)
+	if err == nil {
+		e.writeUint64(code<<8 | uint64(sym.Precision))
+	}
+	return err
+}
+
+func readABISymbol(d *decoder) (Symbol, error) {
+	v, err := d.readUint64()
+	if err != nil {
+		return Symbol{}, err
+	}
+
+	code, err := uint64ToSymbolCode(v >> 8)
+	if err != nil {
+		return Symbol{}, err
+	}
+
+	if v&0xff > MaxSymbolPrecision {
+		return Symbol{}, fmt.Errorf("invalid precision %d", v&0xff)
+	}
+	return Symbol{Precision: uint8(v & 0xff), Code: code}, nil
+}
+
+func encodeABISymbol(e *encoder, v interface{}) error {
+	str, err := abiString(v)
+	if err != nil {
+		return err
+	}
+
+	sym, err := ParseSymbol(str)
+	if err != nil {
+		return err
+	}
+	return writeABISymbol(e, sym)
+}
+
+func decodeABISymbol(d *decoder, s *jsoniter.Stream) error {
+	sym, err := readABISymbol(d)
+	if err == nil {
+		s.WriteString(sym.String())
+	}
+	return err
+}
+
+func encodeABISymbolCode(e *encoder, v interface{}) error {
+	str, err := abiString(v)
+	if err != nil {
+		return err
+	}
+
+	code, err := symbolCodeToUint64(str)
+	if err == nil {
+		e.writeUint64(code)
+	}
+	return err
+}
+
+func decodeABISymbolCode(d *decoder, s *jsoniter.Stream) error {
+	v, err := d.readUint64()
+	if err != nil {
+		return err
+	}
+
+	code, err := uint64ToSymbolCode(v)
+	if err == nil {
+		s.WriteString(code)
+	}
+	return err
+}
+
+func writeABIAsset(e *encoder, a Asset) error {
+	e.writeUint64(uint64(a.Amount))
+	return writeABISymbol(e, a.Symbol)
+}
+
+func readABIAsset(d *decoder) (Asset, error) {
+	amount, err := d.readUint64()
+	if err != nil {
+		return Asset{}, err
+	}
+
+	sym, err := readABISymbol(d)
+	return Asset{Amount: int64(amount), Symbol: sym}, err
+}
+
+func encodeABIAsset(e *encoder, v interface{}) error {
+	str, err := abiString(v)
+	if err != nil {
+		return err
+	}
+
+	a, err := ParseAsset(str)
+	if err != nil {
+		return err
+	}
+	return writeABIAsset(e, a)
+}
+
+func decodeABIAsset(d *decoder, s *jsoniter.Stream) error {
+	a, err := readABIAsset(d)
+	if err == nil {
+		s.WriteString(a.String())
+	}
+	return err
+}
+
+// extended_asset is encoded as {"quantity": asset, "contract": name} in json.
+func encodeABIExtendedAsset(e *encoder, v interface{}) error {
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("expected object, got %T", v)
+	}
+
+	if err := encodeABIAsset(e, obj["quantity"]); err != nil {
+		return fmt.Errorf("quantity: %v", err)
+	}
+
+	if err := encodeABIName(e, obj["contract"]); err != nil {
+		return fmt.Errorf("contract: %v", err)
+	}
+	return nil
+}
+
+func decodeABIExtendedAsset(d *decoder, s *jsoniter.Stream) error {
+	a, err := readABIAsset(d)
+	if err != nil {
+		return err
+	}
+
+	contract, err := d.readUint64()
+	if err != nil {
+		return err
+	}
+
+	s.WriteObjectStart()
+	s.WriteObjectField("quantity")
+	s.WriteString(a.String())
+	s.WriteMore()
+	s.WriteObjectField("contract")
+	s.WriteString(uint64ToName(contract))
+	s.WriteObjectEnd()
+	return nil
+}
@@ -0,0 +1,171 @@
+package leapapi
+
+import (
+	"encoding/hex"
+	"io/ioutil"
+	"testing"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type abiFixture struct {
+	Name string              `json:"name"`
+	JSON jsoniter.RawMessage `json:"json"`
+	Hex  string              `json:"hex"`
+}
+
+type abiFixtures struct {
+	ABI     ABI          `json:"abi"`
+	Actions []abiFixture `json:"actions"`
+	Tables  []abiFixture `json:"tables"`
+}
+
+func loadABIFixtures(t *testing.T) abiFixtures {
+	var fixtures abiFixtures
+
+	data, err := ioutil.ReadFile("testdata/abi_fixtures.json")
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &fixtures))
+	return fixtures
+}
+
+func TestABI_Actions(t *testing.T) {
+	fixtures := loadABIFixtures(t)
+
+	for _, f := range fixtures.Actions {
+		t.Run(f.Name, func(t *testing.T) {
+			bin, err := fixtures.ABI.EncodeAction(f.Name, f.JSON)
+			require.NoError(t, err)
+			assert.Equal(t, f.Hex, hex.EncodeToString(bin))
+
+			out, err := fixtures.ABI.DecodeAction(f.Name, bin)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(f.JSON), string(out))
+		})
+	}
+}
+
+func TestABI_TableRows(t *testing.T) {
+	fixtures := loadABIFixtures(t)
+
+	for _, f := range fixtures.Tables {
+		t.Run(f.Name, func(t *testing.T) {
+			bin, err := fixtures.ABI.EncodeTableRow(f.Name, f.JSON)
+			require.NoError(t, err)
+			assert.Equal(t, f.Hex, hex.EncodeToString(bin))
+
+			out, err := fixtures.ABI.DecodeTableRow(f.Name, bin)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(f.JSON), string(out))
+		})
+	}
+}
+
+func TestABI_EncodeStruct(t *testing.T) {
+	fixtures := loadABIFixtures(t)
+
+	type transfer struct {
+		From     string `json:"from"`
+		To       string `json:"to"`
+		Quantity Asset  `json:"quantity"`
+		Memo     string `json:"memo"`
+	}
+
+	bin, err := fixtures.ABI.EncodeAction("transfer", transfer{
+		From:     "eosio",
+		To:       "eosio.token",
+		Quantity: Asset{Amount: 10000, Symbol: Symbol{Precision: 4, Code: "EOS"}},
+		Memo:     "hi",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, fixtures.Actions[0].Hex, hex.EncodeToString(bin))
+}
+
+func TestABI_BinaryExtension(t *testing.T) {
+	abi := ABI{
+		Structs: []ABIStruct{{Name: "s", Fields: []ABIField{
+			{Name: "a", Type: "uint8"},
+			{Name: "b", Type: "uint8$"},
+		}}},
+	}
+
+	bin, err := abi.EncodeType("s", jsoniter.RawMessage(`{"a":1}`))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1}, bin)
+
+	out, err := abi.DecodeType("s", bin)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"a":1}`, string(out))
+
+	out, err = abi.DecodeType("s", []byte{1, 2})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"a":1,"b":2}`, string(out))
+}
+
+func TestABI_TimePointMaximum(t *testing.T) {
+	abi := ABI{}
+
+	out, err := abi.DecodeType("time_point", []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f})
+	require.NoError(t, err)
+	assert.Equal(t, `"294247-01-10T04:00:54.775"`, string(out))
+}
+
+func TestABI_Errors(t *testing.T) {
+	fixtures := loadABIFixtures(t)
+	abi := fixtures.ABI
+
+	tests := []struct {
+		name string
+		fn   func() error
+		err  string
+	}{
+		{"unknown action", func() error {
+			_, err := abi.EncodeAction("nope", nil)
+			return err
+		}, `abi: action "nope" not found`},
+		{"unknown table", func() error {
+			_, err := abi.DecodeTableRow("nope", nil)
+			return err
+		}, `abi: table "nope" not found`},
+		{"unknown type", func() error {
+			_, err := abi.EncodeType("nope", 1)
+			return err
+		}, `abi: unknown type "nope"`},
+		{"missing field", func() error {
+			_, err := abi.EncodeAction("transfer", jsoniter.RawMessage(`{"from":"eosio"}`))
+			return err
+		}, `abi: transfer: missing field "to"`},
+		{"invalid name", func() error {
+			_, err := abi.EncodeType("name", "EOSIO")
+			return err
+		}, `abi: name: name: "EOSIO" is not a valid name`},
+		{"out of range", func() error {
+			_, err := abi.EncodeType("uint8", 256)
+			return err
+		}, `abi: uint8: strconv.ParseUint: parsing "256": value out of range`},
+		{"short data", func() error {
+			_, err := abi.DecodeType("uint32", []byte{1, 2})
+			return err
+		}, `abi: uint32: unexpected EOF`},
+		{"trailing data", func() error {
+			_, err := abi.DecodeType("uint8", []byte{1, 2})
+			return err
+		}, `abi: 1 bytes left after decoding uint8`},
+		{"array too long", func() error {
+			_, err := abi.DecodeType("names", []byte{0xff, 0xff, 0xff, 0xff, 0x0f})
+			return err
+		}, `abi: account_name[]: unexpected EOF`},
+		{"invalid variant", func() error {
+			_, err := abi.DecodeType("item", []byte{5})
+			return err
+		}, `abi: item: invalid variant index 5`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.EqualError(t, test.fn(), test.err)
+		})
+	}
+}
@@ -0,0 +1,287 @@
+package leapapi
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTokenABI = `{
+  "version": "eosio::abi/1.2",
+  "types": [{"new_type_name": "account_name", "type": "name"}],
+  "structs": [
+    {
+      "name": "transfer",
+      "base": "",
+      "fields": [
+        {"name": "from", "type": "name"},
+        {"name": "to", "type": "name"},
+        {"name": "quantity", "type": "asset"},
+        {"name": "memo", "type": "string"}
+      ]
+    },
+    {"name": "account", "base": "", "fields": [{"name": "balance", "type": "asset"}]}
+  ],
+  "actions": [{"name": "transfer", "type": "transfer", "ricardian_contract": "---\ntitle: Transfer"}],
+  "tables": [{"name": "accounts", "index_type": "i64", "key_names": [], "key_types": [], "type": "account"}],
+  "ricardian_clauses": [{"id": "UserAgreement", "body": "Be nice"}],
+  "error_messages": [{"error_code": 1, "error_msg": "failed"}, {"error_code": "18446744073709551615", "error_msg": "overflow"}],
+  "abi_extensions": [],
+  "variants": [{"name": "variant_int_string", "types": ["int32", "string"]}],
+  "action_results": [{"name": "transfer", "result_type": "bool"}],
+  "kv_tables": {
+    "kvaccounts": {
+      "type": "account",
+      "primary_index": {"name": "primarykey", "type": "name"},
+      "secondary_indices": {"bybalance": {"type": "asset"}}
+    }
+  }
+}`
+
+func TestABI_JsonDecode(t *testing.T) {
+	var abi ABI
+	err := json.Unmarshal([]byte(testTokenABI), &abi)
+	require.NoError(t, err)
+
+	assert.Equal(t, "eosio::abi/1.2", abi.Version)
+	assert.Equal(t, []ABIType{{NewTypeName: "account_name", Type: "name"}}, abi.Types)
+	require.Len(t, abi.Structs, 2)
+	assert.Equal(t, ABIStruct{
+		Name: "transfer",
+		Fields: []ABIField{
+			{Name: "from", Type: "name"},
+			{Name: "to", Type: "name"},
+			{Name: "quantity", Type: "asset"},
+			{Name: "memo", Type: "string"},
+		},
+	}, abi.Structs[0])
+	assert.Equal(t, []ABIAction{{Name: "transfer", Type: "transfer", RicardianContract: "---\ntitle: Transfer"}}, abi.Actions)
+	assert.Equal(t, []ABITable{{Name: "accounts", IndexType: "i64", KeyNames: []string{}, KeyTypes: []string{}, Type: "account"}}, abi.Tables)
+	assert.Equal(t, []ABIClause{{ID: "UserAgreement", Body: "Be nice"}}, abi.RicardianClauses)
+	assert.Equal(t, []ABIErrorMessage{
+		{Code: 1, Message: "failed"},
+		{Code: 18446744073709551615, Message: "overflow"},
+	}, abi.ErrorMessages)
+	assert.Equal(t, []ABIVariant{{Name: "variant_int_string", Types: []string{"int32", "string"}}}, abi.Variants)
+	assert.Equal(t, []ABIActionResult{{Name: "transfer", ResultType: "bool"}}, abi.ActionResults)
+	assert.Equal(t, map[string]ABIKVTable{
+		"kvaccounts": {
+			Type:             "account",
+			PrimaryIndex:     ABIKVIndex{Name: "primarykey", Type: "name"},
+			SecondaryIndices: map[string]ABIKVIndex{"bybalance": {Type: "asset"}},
+		},
+	}, abi.KVTables)
+}
+
+func TestGetABI(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v1/chain/get_abi", req.URL.String())
+
+		body, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"account_name": "eosio.token"}`, string(body))
+
+		_, _ = res.Write([]byte(`{"account_name": "eosio.token", "abi": ` + testTokenABI + `}`))
+	}))
+
+	client := New(srv.URL)
+	abi, err := client.GetABI(context.Background(), "eosio.token")
+
+	require.NoError(t, err)
+	assert.Equal(t, Name("eosio.token"), abi.AccountName)
+	require.NotNil(t, abi.ABI)
+	assert.Equal(t, "eosio::abi/1.2", abi.ABI.Version)
+}
+
+func TestGetABINoContract(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		_, _ = res.Write([]byte(`{"account_name": "someaccount1"}`))
+	}))
+
+	client := New(srv.URL)
+	abi, err := client.GetABI(context.Background(), "someaccount1")
+
+	require.NoError(t, err)
+	assert.Nil(t, abi.ABI)
+}
+
+func TestGetRawABI(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v1/chain/get_raw_abi", req.URL.String())
+
+		payload := `{
+            "account_name": "eosio.token",
+            "code_hash": "01bd013c4f8be142b9cadf511f007c6ac201c068d529f01ed5661803c575befa",
+            "abi_hash": "f8f677996a8ca68388bc41cf55e727948c161b3cf4cd7a4e18e0d1c5d4c8e9bd",
+            "abi": "DmVvc2lvOjphYmkvMS4y"
+        }`
+		_, _ = res.Write([]byte(payload))
+	}))
+
+	client := New(srv.URL)
+	abi, err := client.GetRawABI(context.Background(), "eosio.token")
+
+	require.NoError(t, err)
+	assert.Equal(t, "01bd013c4f8be142b9cadf511f007c6ac201c068d529f01ed5661803c575befa", abi.CodeHash)
+	assert.Equal(t, "f8f677996a8ca68388bc41cf55e727948c161b3cf4cd7a4e18e0d1c5d4c8e9bd", abi.ABIHash)
+	assert.Equal(t, append([]byte{14}, "eosio::abi/1.2"...), abi.ABI)
+}
+
+func TestGetCode(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v1/chain/get_code", req.URL.String())
+
+		body, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"account_name": "eosio.token", "code_as_wasm": true}`, string(body))
+
+		payload := `{
+            "account_name": "eosio.token",
+            "code_hash": "01bd013c4f8be142b9cadf511f007c6ac201c068d529f01ed5661803c575befa",
+            "wast": "",
+            "wasm": "0061736d01000000",
+            "abi": ` + testTokenABI + `
+        }`
+		_, _ = res.Write([]byte(payload))
+	}))
+
+	client := New(srv.URL)
+	code, err := client.GetCode(context.Background(), "eosio.token")
+
+	require.NoError(t, err)
+	assert.Equal(t, "01bd013c4f8be142b9cadf511f007c6ac201c068d529f01ed5661803c575befa", code.CodeHash)
+	assert.Equal(t, "0061736d01000000", code.WASM)
+	require.NotNil(t, code.ABI)
+	assert.Len(t, code.ABI.Structs, 2)
+}
+
+func TestGetCodeHash(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v1/chain/get_code_hash", req.URL.String())
+		_, _ = res.Write([]byte(`{"account_name": "eosio.token", "code_hash": "01bd013c4f8be142b9cadf511f007c6ac201c068d529f01ed5661803c575befa"}`))
+	}))
+
+	client := New(srv.URL)
+	hash, err := client.GetCodeHash(context.Background(), "eosio.token")
+
+	require.NoError(t, err)
+	assert.Equal(t, CodeHash{AccountName: "eosio.token", CodeHash: "01bd013c4f8be142b9cadf511f007c6ac201c068d529f01ed5661803c575befa"}, hash)
+}
+
+func TestGetRawCodeAndABI(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v1/chain/get_raw_code_and_abi", req.URL.String())
+		_, _ = res.Write([]byte(`{"account_name": "eosio.token", "wasm": "AGFzbQEAAAA=", "abi": "DmVvc2lvOjphYmkvMS4y"}`))
+	}))
+
+	client := New(srv.URL)
+	res, err := client.GetRawCodeAndABI(context.Background(), "eosio.token")
+
+	require.NoError(t, err)
+	assert.Equal(t, Name("eosio.token"), res.AccountName)
+	assert.Equal(t, []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}, res.WASM)
+	assert.Equal(t, append([]byte{14}, "eosio::abi/1.2"...), res.ABI)
+}
+
+func TestABIJSONToBin(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v1/chain/abi_json_to_bin", req.URL.String())
+
+		body, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+            "code": "eosio.token",
+            "action": "transfer",
+            "args": {"from": "eosio", "to": "eosio.token", "quantity": "1.0000 EOS", "memo": "hi"}
+        }`, string(body))
+
+		_, _ = res.Write([]byte(`{"binargs": "0000000000ea305500a6823403ea3055102700000000000004454f5300000000026869"}`))
+	}))
+
+	client := New(srv.URL)
+	res, err := client.ABIJSONToBin(context.Background(), ABIJSONToBinRequest{
+		Code:   "eosio.token",
+		Action: "transfer",
+		Args: struct {
+			From     string `json:"from"`
+			To       string `json:"to"`
+			Quantity string `json:"quantity"`
+			Memo     string `json:"memo"`
+		}{"eosio", "eosio.token", "1.0000 EOS", "hi"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "0000000000ea305500a6823403ea3055102700000000000004454f5300000000026869", res.Binargs.String())
+	assert.Len(t, res.Binargs, 35)
+}
+
+func TestABIBinToJSON(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v1/chain/abi_bin_to_json", req.URL.String())
+
+		body, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"code": "eosio.token", "action": "transfer", "binargs": "0000000000ea3055"}`, string(body))
+
+		_, _ = res.Write([]byte(`{"args": {"from": "eosio", "to": "eosio.token", "quantity": "1.0000 EOS", "memo": "hi"}}`))
+	}))
+
+	client := New(srv.URL)
+	res, err := client.ABIBinToJSON(context.Background(), ABIBinToJSONRequest{
+		Code:    "eosio.token",
+		Action:  "transfer",
+		Binargs: HexBytes{0, 0, 0, 0, 0, 0xea, 0x30, 0x55},
+	})
+	require.NoError(t, err)
+
+	var args struct {
+		From     string `json:"from"`
+		Quantity Asset  `json:"quantity"`
+	}
+	require.NoError(t, res.Decode(&args))
+	assert.Equal(t, "eosio", args.From)
+	assert.Equal(t, "1.0000 EOS", args.Quantity.String())
+}
+
+func TestABIBinToJSONError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		payload := `{
+            "code": 500,
+            "message": "Internal Service Error",
+            "error": {
+                "code": 3015014,
+                "name": "pack_exception",
+                "what": "Pack data exception",
+                "details": [
+                    {
+                        "message": "Unable to unpack built-in type 'name' while processing 'transfer.to'",
+                        "file": "abi_serializer.cpp",
+                        "line_number": 165,
+                        "method": "_binary_to_variant"
+                    }
+                ]
+            }
+        }`
+		res.WriteHeader(500)
+		_, _ = res.Write([]byte(payload))
+	}))
+
+	client := New(srv.URL)
+	_, err := client.ABIBinToJSON(context.Background(), ABIBinToJSONRequest{Code: "eosio.token", Action: "transfer"})
+	require.EqualError(t, err, "500 Internal Service Error")
+
+	api_err, ok := err.(APIError)
+	require.True(t, ok)
+	assert.Equal(t, "pack_exception", api_err.Err.Name)
+	assert.Equal(t, []APIErrorDetail{{
+		Message: "Unable to unpack built-in type 'name' while processing 'transfer.to'",
+		File:    "abi_serializer.cpp",
+		Line:    165,
+		Method:  "_binary_to_variant",
+	}}, api_err.Err.Details)
+}
@@ -0,0 +1,172 @@
+package leapapi
+
+import (
+	"time"
+)
+
+// Permission level and weight pair.
+type PermissionLevelWeight struct {
+	Permission PermissionLevel `json:"permission"`
+	Weight     uint16          `json:"weight"`
+}
+
+// Wait and weight pair.
+type WaitWeight struct {
+	WaitSec uint32 `json:"wait_sec"`
+	Weight  uint16 `json:"weight"`
+}
+
+// Authority format
+type Authority struct {
+	Threshold uint32                  `json:"threshold"`
+	Keys      []KeyWeight             `json:"keys"`
+	Accounts  []PermissionLevelWeight `json:"accounts"`
+	Waits     []WaitWeight            `json:"waits"`
+}
+
+// Action linked to a permission.
+type LinkedAction struct {
+	Account Name `json:"account"`
+	Action  Name `json:"action,omitempty"`
+}
+
+// Account permission format
+type Permission struct {
+	Name          Name           `json:"perm_name"`
+	Parent        Name           `json:"parent"`
+	RequiredAuth  Authority      `json:"required_auth"`
+	LinkedActions []LinkedAction `json:"linked_actions,omitempty"`
+}
+
+// Account resource limit format (cpu and net)
+type AccountResourceLimit struct {
+	Used                Int64     `json:"used"`
+	Available           Int64     `json:"available"`
+	Max                 Int64     `json:"max"`
+	LastUsageUpdateTime time.Time `json:"last_usage_update_time" time_format:"2006-01-02T15:04:05.000"`
+	CurrentUsed         Int64     `json:"current_used"`
+}
+
+// Total resources staked to an account.
+type TotalResources struct {
+	Owner     Name  `json:"owner"`
+	NETWeight Asset `json:"net_weight"`
+	CPUWeight Asset `json:"cpu_weight"`
+	RAMBytes  Int64 `json:"ram_bytes"`
+}
+
+// Bandwidth delegated from one account to another.
+type DelegatedBandwidth struct {
+	From      Name  `json:"from"`
+	To        Name  `json:"to"`
+	NETWeight Asset `json:"net_weight"`
+	CPUWeight Asset `json:"cpu_weight"`
+}
+
+// Pending refund of unstaked tokens.
+type RefundRequest struct {
+	Owner       Name      `json:"owner"`
+	RequestTime time.Time `json:"request_time"`
+	NETAmount   Asset     `json:"net_amount"`
+	CPUAmount   Asset     `json:"cpu_amount"`
+}
+
+// Voter info format
+type VoterInfo struct {
+	Owner             Name       `json:"owner"`
+	Proxy             Name       `json:"proxy"`
+	Producers         []Name     `json:"producers"`
+	Staked            Int64      `json:"staked"`
+	LastVoteWeight    VoteWeight `json:"last_vote_weight"`
+	ProxiedVoteWeight VoteWeight `json:"proxied_vote_weight"`
+	IsProxy           uint8      `json:"is_proxy"`
+	Flags1            uint32     `json:"flags1"`
+	Reserved2         uint32     `json:"reserved2"`
+	Reserved3         Asset      `json:"reserved3"`
+}
+
+// REX maturity bucket.
+type RexMaturity struct {
+	Time   time.Time
+	Amount int64
+}
+
+func (m RexMaturity) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Key   time.Time `json:"key"`
+		Value int64     `json:"value"`
+	}{m.Time, m.Amount})
+}
+
+// Older nodes encode maturities as {"first": ..., "second": ...}
+// and newer ones as {"key": ..., "value": ...}. Both are accepted.
+func (m *RexMaturity) UnmarshalJSON(b []byte) error {
+	var r struct {
+		First  *time.Time `json:"first"`
+		Second int64      `json:"second"`
+		Key    *time.Time `json:"key"`
+		Value  int64      `json:"value"`
+	}
+
+	err := json.Unmarshal(b, &r)
+	if err == nil {
+		if r.Key != nil {
+			m.Time = *r.Key
+			m.Amount = r.Value
+		} else if r.First != nil {
+			m.Time = *r.First
+			m.Amount = r.Second
+		}
+	}
+	return err
+}
+
+// REX info format
+type RexInfo struct {
+	Version       uint32        `json:"version"`
+	Owner         Name          `json:"owner"`
+	VoteStake     Asset         `json:"vote_stake"`
+	RexBalance    Asset         `json:"rex_balance"`
+	MaturedRex    int64         `json:"matured_rex"`
+	RexMaturities []RexMaturity `json:"rex_maturities"`
+}
+
+// get_account format
+type Account struct {
+	AccountName            Name                 `json:"account_name"`
+	HeadBlockNum           int64                `json:"head_block_num"`
+	HeadBlockTime          time.Time            `json:"head_block_time" time_format:"2006-01-02T15:04:05.000"`
+	Privileged             bool                 `json:"privileged"`
+	LastCodeUpdate         time.Time            `json:"last_code_update" time_format:"2006-01-02T15:04:05.000"`
+	Created                time.Time            `json:"created" time_format:"2006-01-02T15:04:05.000"`
+	CoreLiquidBalance      *Asset               `json:"core_liquid_balance,omitempty"`
+	RAMQuota               Int64                `json:"ram_quota"`
+	NETWeight              Int64                `json:"net_weight"`
+	CPUWeight              Int64                `json:"cpu_weight"`
+	NETLimit               AccountResourceLimit `json:"net_limit"`
+	CPULimit               AccountResourceLimit `json:"cpu_limit"`
+	RAMUsage               Int64                `json:"ram_usage"`
+	Permissions            []Permission         `json:"permissions"`
+	TotalResources         *TotalResources      `json:"total_resources"`
+	SelfDelegatedBandwidth *DelegatedBandwidth  `json:"self_delegated_bandwidth"`
+	RefundRequest          *RefundRequest       `json:"refund_request"`
+	VoterInfo              *VoterInfo           `json:"voter_info"`
+	RexInfo                *RexInfo             `json:"rex_info"`
+}
+
+// Account permission that is satisfied (fully or partially) by an authorizer.
+//
+// Either AuthorizingAccount or AuthorizingKey is set.
+type AccountAuthorizer struct {
+	AccountName        Name             `json:"account_name"`
+	PermissionName     Name             `json:"permission_name"`
+	AuthorizingAccount *PermissionLevel `json:"authorizing_account,omitempty"`
+	AuthorizingKey     PublicKey        `json:"authorizing_key"`
+	Weight             uint16           `json:"weight"`
+	Threshold          uint32           `json:"threshold"`
+}
+
+// get_accounts_by_authorizers format
+type AccountsByAuthorizers struct {
+	Accounts []AccountAuthorizer `json:"accounts"`
+}
@@ -0,0 +1,307 @@
+package leapapi
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccount_JsonDecode(t *testing.T) {
+	payload := `{
+  "account_name": "someaccount1",
+  "head_block_num": 243071934,
+  "head_block_time": "2023-01-17T09:41:23.500",
+  "privileged": false,
+  "last_code_update": "1970-01-01T00:00:00.000",
+  "created": "2019-04-12T09:12:51.000",
+  "core_liquid_balance": "125.3021 EOS",
+  "ram_quota": 5430,
+  "net_weight": 10000,
+  "cpu_weight": 90000,
+  "net_limit": {
+    "used": 2315,
+    "available": 1284317,
+    "max": 1286632,
+    "last_usage_update_time": "2023-01-16T18:02:11.000",
+    "current_used": 1870
+  },
+  "cpu_limit": {
+    "used": 4521,
+    "available": 11223,
+    "max": 15744,
+    "last_usage_update_time": "2023-01-16T18:02:11.000",
+    "current_used": 3650
+  },
+  "ram_usage": 3466,
+  "permissions": [
+    {
+      "perm_name": "active",
+      "parent": "owner",
+      "required_auth": {
+        "threshold": 2,
+        "keys": [{"key": "EOS6MRyAjQq8ud7hVNYcfnVPJqcVpscN5So8BhtHuGYqET5GDW5CV", "weight": 1}],
+        "accounts": [{"permission": {"actor": "someaccount2", "permission": "eosio.code"}, "weight": 1}],
+        "waits": [{"wait_sec": 3600, "weight": 1}]
+      },
+      "linked_actions": [{"account": "eosio.token", "action": "transfer"}]
+    },
+    {
+      "perm_name": "owner",
+      "parent": "",
+      "required_auth": {
+        "threshold": 1,
+        "keys": [{"key": "EOS6MRyAjQq8ud7hVNYcfnVPJqcVpscN5So8BhtHuGYqET5GDW5CV", "weight": 1}],
+        "accounts": [],
+        "waits": []
+      },
+      "linked_actions": []
+    }
+  ],
+  "total_resources": {
+    "owner": "someaccount1",
+    "net_weight": "1.0000 EOS",
+    "cpu_weight": "9.0000 EOS",
+    "ram_bytes": 4030
+  },
+  "self_delegated_bandwidth": {
+    "from": "someaccount1",
+    "to": "someaccount1",
+    "net_weight": "1.0000 EOS",
+    "cpu_weight": "9.0000 EOS"
+  },
+  "refund_request": {
+    "owner": "someaccount1",
+    "request_time": "2023-01-15T12:00:00",
+    "net_amount": "0.5000 EOS",
+    "cpu_amount": "0.0000 EOS"
+  },
+  "voter_info": {
+    "owner": "someaccount1",
+    "proxy": "",
+    "producers": ["aus1genereos", "eosnationftw"],
+    "staked": 100000,
+    "last_vote_weight": "1234567.89012345671398752",
+    "proxied_vote_weight": "0.00000000000000000",
+    "is_proxy": 0,
+    "flags1": 0,
+    "reserved2": 0,
+    "reserved3": "0.0000 EOS"
+  },
+  "rex_info": {
+    "version": 0,
+    "owner": "someaccount1",
+    "vote_stake": "10.0000 EOS",
+    "rex_balance": "99812.3457 REX",
+    "matured_rex": 123,
+    "rex_maturities": [{"key": "2023-01-21T00:00:00", "value": 4567}]
+  }
+}`
+
+	var account Account
+	err := json.Unmarshal([]byte(payload), &account)
+	require.NoError(t, err)
+
+	eos := Symbol{Precision: 4, Code: "EOS"}
+
+	assert.Equal(t, Name("someaccount1"), account.AccountName)
+	assert.Equal(t, time.Date(2019, 4, 12, 9, 12, 51, 0, time.UTC), account.Created)
+	require.NotNil(t, account.CoreLiquidBalance)
+	assert.Equal(t, Asset{Amount: 1253021, Symbol: eos}, *account.CoreLiquidBalance)
+	assert.Equal(t, Int64(5430), account.RAMQuota)
+	assert.Equal(t, Int64(3466), account.RAMUsage)
+
+	assert.Equal(t, AccountResourceLimit{
+		Used:                4521,
+		Available:           11223,
+		Max:                 15744,
+		LastUsageUpdateTime: time.Date(2023, 1, 16, 18, 2, 11, 0, time.UTC),
+		CurrentUsed:         3650,
+	}, account.CPULimit)
+	assert.Equal(t, Int64(1286632), account.NETLimit.Max)
+
+	require.Len(t, account.Permissions, 2)
+	assert.Equal(t, Permission{
+		Name:   "active",
+		Parent: "owner",
+		RequiredAuth: Authority{
+			Threshold: 2,
+			Keys:      []KeyWeight{{Key: mustParsePublicKey("EOS6MRyAjQq8ud7hVNYcfnVPJqcVpscN5So8BhtHuGYqET5GDW5CV"), Weight: 1}},
+			Accounts: []PermissionLevelWeight{
+				{Permission: PermissionLevel{Actor: "someaccount2", Permission: "eosio.code"}, Weight: 1},
+			},
+			Waits: []WaitWeight{{WaitSec: 3600, Weight: 1}},
+		},
+		LinkedActions: []LinkedAction{{Account: "eosio.token", Action: "transfer"}},
+	}, account.Permissions[0])
+
+	require.NotNil(t, account.TotalResources)
+	assert.Equal(t, Asset{Amount: 90000, Symbol: eos}, account.TotalResources.CPUWeight)
+	assert.Equal(t, Int64(4030), account.TotalResources.RAMBytes)
+
+	require.NotNil(t, account.SelfDelegatedBandwidth)
+	assert.Equal(t, Asset{Amount: 10000, Symbol: eos}, account.SelfDelegatedBandwidth.NETWeight)
+
+	require.NotNil(t, account.RefundRequest)
+	assert.Equal(t, time.Date(2023, 1, 15, 12, 0, 0, 0, time.UTC), account.RefundRequest.RequestTime)
+	assert.Equal(t, Asset{Amount: 5000, Symbol: eos}, account.RefundRequest.NETAmount)
+
+	require.NotNil(t, account.VoterInfo)
+	assert.Equal(t, []Name{"aus1genereos", "eosnationftw"}, account.VoterInfo.Producers)
+	assert.Equal(t, Int64(100000), account.VoterInfo.Staked)
+	assert.Equal(t, VoteWeight(1234567.89012345671398752), account.VoterInfo.LastVoteWeight)
+
+	require.NotNil(t, account.RexInfo)
+	assert.Equal(t, Asset{Amount: 998123457, Symbol: Symbol{Precision: 4, Code: "REX"}}, account.RexInfo.RexBalance)
+	assert.Equal(t, []RexMaturity{
+		{Time: time.Date(2023, 1, 21, 0, 0, 0, 0, time.UTC), Amount: 4567},
+	}, account.RexInfo.RexMaturities)
+}
+
+func TestAccount_JsonDecodeMinimal(t *testing.T) {
+	payload := `{
+  "account_name": "eosio",
+  "privileged": true,
+  "created": "2018-06-08T08:08:08.500",
+  "permissions": [],
+  "total_resources": null,
+  "self_delegated_bandwidth": null,
+  "refund_request": null,
+  "voter_info": null,
+  "rex_info": null
+}`
+
+	var account Account
+	err := json.Unmarshal([]byte(payload), &account)
+	require.NoError(t, err)
+
+	assert.Equal(t, Name("eosio"), account.AccountName)
+	assert.True(t, account.Privileged)
+	assert.Equal(t, time.Date(2018, 6, 8, 8, 8, 8, 500000000, time.UTC), account.Created)
+	assert.Nil(t, account.CoreLiquidBalance)
+	assert.Nil(t, account.TotalResources)
+	assert.Nil(t, account.SelfDelegatedBandwidth)
+	assert.Nil(t, account.RefundRequest)
+	assert.Nil(t, account.VoterInfo)
+	assert.Nil(t, account.RexInfo)
+}
+
+func TestAccount_JsonDecodeQuoted(t *testing.T) {
+	payload := `{
+  "account_name": "eosio",
+  "created": "2018-06-08T08:08:08.500",
+  "ram_quota": "-1",
+  "net_weight": "50000000000",
+  "cpu_weight": "9223372036854775807",
+  "ram_usage": "3466",
+  "net_limit": {"used": "2315", "available": "-1", "max": "-1", "current_used": "1870"},
+  "cpu_limit": {"used": 4521, "available": 11223, "max": 15744, "current_used": 3650},
+  "permissions": [],
+  "total_resources": {"owner": "eosio", "net_weight": "1.0000 EOS", "cpu_weight": "9.0000 EOS", "ram_bytes": "50000000000"},
+  "voter_info": {"owner": "eosio", "proxy": "", "producers": [], "staked": "50000000000", "last_vote_weight": "0.0", "proxied_vote_weight": "0.0"}
+}`
+
+	var account Account
+	err := json.Unmarshal([]byte(payload), &account)
+	require.NoError(t, err)
+
+	assert.Equal(t, Int64(-1), account.RAMQuota)
+	assert.Equal(t, Int64(50000000000), account.NETWeight)
+	assert.Equal(t, Int64(9223372036854775807), account.CPUWeight)
+	assert.Equal(t, Int64(3466), account.RAMUsage)
+	assert.Equal(t, AccountResourceLimit{Used: 2315, Available: -1, Max: -1, CurrentUsed: 1870}, account.NETLimit)
+	assert.Equal(t, AccountResourceLimit{Used: 4521, Available: 11223, Max: 15744, CurrentUsed: 3650}, account.CPULimit)
+	require.NotNil(t, account.TotalResources)
+	assert.Equal(t, Int64(50000000000), account.TotalResources.RAMBytes)
+	require.NotNil(t, account.VoterInfo)
+	assert.Equal(t, Int64(50000000000), account.VoterInfo.Staked)
+}
+
+func TestAccount_JsonDecodeQuotedInvalid(t *testing.T) {
+	var account Account
+	err := json.Unmarshal([]byte(`{"net_weight": "50EOS"}`), &account)
+	assert.Error(t, err)
+}
+
+func TestRexMaturity_JsonDecodeLegacy(t *testing.T) {
+	var m RexMaturity
+	err := json.Unmarshal([]byte(`{"first": "2020-05-01T00:00:00", "second": 100}`), &m)
+	require.NoError(t, err)
+	assert.Equal(t, RexMaturity{Time: time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC), Amount: 100}, m)
+}
+
+func TestGetAccountsByAuthorizers(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v1/chain/get_accounts_by_authorizers", req.URL.String())
+
+		body, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+            "accounts": ["alice", {"actor": "bob", "permission": "active"}],
+            "keys": ["`+testPublicKey+`"]
+        }`, string(body))
+
+		payload := `{"accounts": [
+            {
+                "account_name": "carol",
+                "permission_name": "owner",
+                "authorizing_key": "` + testPublicKeyK1 + `",
+                "weight": 1,
+                "threshold": 1
+            },
+            {
+                "account_name": "dave",
+                "permission_name": "active",
+                "authorizing_account": {"actor": "bob", "permission": "active"},
+                "weight": 1,
+                "threshold": 2
+            }
+        ]}`
+		_, _ = res.Write([]byte(payload))
+	}))
+
+	client := New(srv.URL)
+	res, err := client.GetAccountsByAuthorizers(context.Background(),
+		[]PermissionLevel{{Actor: "alice"}, {Actor: "bob", Permission: "active"}},
+		[]PublicKey{mustParsePublicKey(testPublicKey)})
+	require.NoError(t, err)
+
+	expected := []AccountAuthorizer{
+		{
+			AccountName:    "carol",
+			PermissionName: "owner",
+			AuthorizingKey: mustParsePublicKey(testPublicKey),
+			Weight:         1,
+			Threshold:      1,
+		},
+		{
+			AccountName:        "dave",
+			PermissionName:     "active",
+			AuthorizingAccount: &PermissionLevel{Actor: "bob", Permission: "active"},
+			Weight:             1,
+			Threshold:          2,
+		},
+	}
+	assert.Equal(t, expected, res.Accounts)
+	assert.True(t, res.Accounts[1].AuthorizingKey.IsEmpty())
+}
+
+func TestGetAccountsByAuthorizersNoKeys(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"accounts": ["alice"], "keys": []}`, string(body))
+
+		_, _ = res.Write([]byte(`{"accounts": []}`))
+	}))
+
+	client := New(srv.URL)
+	res, err := client.GetAccountsByAuthorizers(context.Background(), []PermissionLevel{{Actor: "alice"}}, nil)
+	require.NoError(t, err)
+	assert.Empty(t, res.Accounts)
+}
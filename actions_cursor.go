@@ -0,0 +1,151 @@
+package leapapi
+
+import "context"
+
+// Page size used by ActionsCursor when the request has no limit.
+const defaultActionsCursorLimit = 100
+
+// ActionsCursor options
+type ActionsCursorOptions struct {
+	// Stop at the last irreversible block reported by hyperion.
+	StopAtLib bool
+}
+
+// ActionsCursor walks all actions matching a get_actions request in
+// ascending order.
+//
+// Instead of skip/limit (which yields duplicates and gaps when new actions
+// are indexed during the scan) pages are requested from the block of the last
+// seen action, skipping the actions of that block already seen. Actions that
+// are seen again anyway (by global sequence) are dropped.
+//
+// The end is reached at an empty page (or a page of only seen actions), not at
+// a short page as hyperion may return fewer actions than the limit.
+//
+//	cur := client.NewActionsCursor(req, ActionsCursorOptions{})
+//	for cur.Next(ctx) {
+//		action := cur.Action()
+//		...
+//	}
+//	if err := cur.Err(); err != nil {
+//		...
+//	}
+type ActionsCursor struct {
+	client    *Client
+	req       ActionsRequest
+	stopAtLib bool
+	rows      []HyperionAction
+	row       HyperionAction
+	// Global sequence of the last action returned.
+	lastSeq Uint64
+	hasLast bool
+	lib     uint32
+	more    bool
+	err     error
+}
+
+// NewActionsCursor creates a new cursor starting at req.
+//
+// Sort, Skip and Simple in req are ignored.
+func (c *Client) NewActionsCursor(req ActionsRequest, opts ActionsCursorOptions) *ActionsCursor {
+	req.Sort = SortAsc
+	req.Skip = 0
+	req.Simple = false
+	if req.Limit < 1 {
+		req.Limit = defaultActionsCursorLimit
+	}
+
+	if opts.StopAtLib {
+		req.CheckLib = true
+	}
+
+	return &ActionsCursor{
+		client:    c,
+		req:       req,
+		stopAtLib: opts.StopAtLib,
+		more:      true,
+	}
+}
+
+// Next advances the cursor to the next action.
+// Returns false when there are no more actions or an error occurred.
+func (cur *ActionsCursor) Next(ctx context.Context) bool {
+	for len(cur.rows) < 1 {
+		if !cur.more || cur.err != nil {
+			return false
+		}
+
+		if err := cur.fetch(ctx); err != nil {
+			cur.err = err
+			return false
+		}
+	}
+
+	cur.row, cur.rows = cur.rows[0], cur.rows[1:]
+	cur.lastSeq = cur.row.GlobalSequence
+	cur.hasLast = true
+	return true
+}
+
+// Fetch the next page and queue the actions not seen before.
+func (cur *ActionsCursor) fetch(ctx context.Context) error {
+	res, err := cur.client.GetActions(ctx, cur.req)
+	if err != nil {
+		return err
+	}
+
+	if res.LIB > 0 {
+		cur.lib = res.LIB
+	}
+
+	rows := make([]HyperionAction, 0, len(res.Actions))
+	for _, action := range res.Actions {
+		if cur.stopAtLib && cur.lib > 0 && action.BlockNum > cur.lib {
+			cur.more = false
+			break
+		}
+
+		if cur.hasLast && action.GlobalSequence <= cur.lastSeq {
+			continue
+		}
+		rows = append(rows, action)
+	}
+
+	// A short page is not the end (hyperion caps the limit),
+	// only an empty page or one with only seen actions is.
+	if len(rows) < 1 {
+		cur.more = false
+	}
+
+	if len(res.Actions) > 0 {
+		last := res.Actions[len(res.Actions)-1].BlockNum
+		if last != cur.req.AfterBlock {
+			cur.req.AfterBlock = last
+			cur.req.Skip = 0
+		}
+
+		// Skip the actions in the last block that are already seen.
+		for i := len(res.Actions) - 1; i >= 0 && res.Actions[i].BlockNum == last; i-- {
+			cur.req.Skip++
+		}
+	}
+
+	cur.rows = rows
+	return nil
+}
+
+// Action returns the current action.
+func (cur *ActionsCursor) Action() HyperionAction {
+	return cur.row
+}
+
+// LIB returns the last irreversible block reported by hyperion
+// (only when StopAtLib or CheckLib is set).
+func (cur *ActionsCursor) LIB() uint32 {
+	return cur.lib
+}
+
+// Err returns the error (if any) that stopped the cursor.
+func (cur *ActionsCursor) Err() error {
+	return cur.err
+}
@@ -0,0 +1,177 @@
+package leapapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testHyperionIndex struct {
+	mu       sync.Mutex
+	actions  [][2]uint64 // block number, global sequence
+	lib      uint32
+	requests []string
+	// Page size cap of the server (0 is no cap).
+	maxLimit int
+	// Called after each request, to simulate new actions being indexed.
+	onRequest func(idx *testHyperionIndex)
+}
+
+func (idx *testHyperionIndex) add(block uint64, n int) {
+	seq := uint64(0)
+	if len(idx.actions) > 0 {
+		seq = idx.actions[len(idx.actions)-1][1]
+	}
+
+	for i := 0; i < n; i++ {
+		seq++
+		idx.actions = append(idx.actions, [2]uint64{block, seq})
+	}
+}
+
+// Minimal get_actions implementation (ascending, after is inclusive).
+func (idx *testHyperionIndex) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	q := req.URL.Query()
+	idx.requests = append(idx.requests, q.Get("after")+"/"+q.Get("skip"))
+
+	after, _ := strconv.ParseUint(q.Get("after"), 10, 32)
+	skip, _ := strconv.Atoi(q.Get("skip"))
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	if idx.maxLimit > 0 && limit > idx.maxLimit {
+		limit = idx.maxLimit
+	}
+
+	items := []string{}
+	for _, a := range idx.actions {
+		if a[0] < after {
+			continue
+		}
+
+		if skip > 0 {
+			skip--
+			continue
+		}
+
+		if len(items) >= limit {
+			break
+		}
+		items = append(items, fmt.Sprintf(`{"block_num": %d, "global_sequence": %d, "act": {"account": "eosio", "name": "onblock"}}`, a[0], a[1]))
+	}
+
+	lib := ""
+	if q.Get("checkLib") == "true" {
+		lib = fmt.Sprintf(`"lib": %d, `, idx.lib)
+	}
+	_, _ = res.Write([]byte(`{` + lib + `"actions": [` + strings.Join(items, ",") + `]}`))
+
+	if idx.onRequest != nil {
+		idx.onRequest(idx)
+	}
+}
+
+func collectCursor(t *testing.T, cur *ActionsCursor) []uint64 {
+	seqs := []uint64{}
+	for cur.Next(context.Background()) {
+		seqs = append(seqs, uint64(cur.Action().GlobalSequence))
+	}
+	require.NoError(t, cur.Err())
+	return seqs
+}
+
+func seqRange(from, to uint64) []uint64 {
+	r := []uint64{}
+	for i := from; i <= to; i++ {
+		r = append(r, i)
+	}
+	return r
+}
+
+func TestActionsCursor(t *testing.T) {
+	idx := &testHyperionIndex{}
+	idx.add(10, 2)
+	idx.add(11, 3)
+	idx.add(12, 1)
+	idx.add(13, 2)
+
+	// New actions are indexed after the first page.
+	idx.onRequest = func(idx *testHyperionIndex) {
+		if len(idx.requests) == 1 {
+			idx.add(14, 2)
+		}
+	}
+
+	srv := httptest.NewServer(idx)
+	client := New(srv.URL)
+
+	cur := client.NewActionsCursor(ActionsRequest{Account: "eosio", Limit: 3, Skip: 5, Sort: SortDesc}, ActionsCursorOptions{})
+	assert.Equal(t, seqRange(1, 10), collectCursor(t, cur))
+	assert.Equal(t, []string{"/", "11/1", "12/1", "14/1", "14/2"}, idx.requests)
+}
+
+func TestActionsCursorLargeBlock(t *testing.T) {
+	idx := &testHyperionIndex{}
+	idx.add(10, 1)
+	idx.add(11, 7)
+	idx.add(12, 1)
+
+	srv := httptest.NewServer(idx)
+	client := New(srv.URL)
+
+	cur := client.NewActionsCursor(ActionsRequest{AfterBlock: 10, Limit: 3}, ActionsCursorOptions{})
+	assert.Equal(t, seqRange(1, 9), collectCursor(t, cur))
+	assert.Equal(t, []string{"10/", "11/2", "11/5", "12/1"}, idx.requests)
+}
+
+func TestActionsCursorServerLimit(t *testing.T) {
+	idx := &testHyperionIndex{maxLimit: 2}
+	idx.add(10, 2)
+	idx.add(11, 3)
+	idx.add(12, 1)
+
+	srv := httptest.NewServer(idx)
+	client := New(srv.URL)
+
+	// Server returns 2 actions per page, fewer than the limit.
+	cur := client.NewActionsCursor(ActionsRequest{Limit: 5}, ActionsCursorOptions{})
+	assert.Equal(t, seqRange(1, 6), collectCursor(t, cur))
+	assert.Equal(t, []string{"/", "10/2", "11/2", "12/1"}, idx.requests)
+}
+
+func TestActionsCursorStopAtLib(t *testing.T) {
+	idx := &testHyperionIndex{lib: 11}
+	idx.add(10, 2)
+	idx.add(11, 2)
+	idx.add(12, 2)
+
+	srv := httptest.NewServer(idx)
+	client := New(srv.URL)
+
+	cur := client.NewActionsCursor(ActionsRequest{Limit: 3}, ActionsCursorOptions{StopAtLib: true})
+	assert.Equal(t, seqRange(1, 4), collectCursor(t, cur))
+	assert.Equal(t, uint32(11), cur.LIB())
+	assert.Equal(t, []string{"/", "11/1"}, idx.requests)
+}
+
+func TestActionsCursorError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.WriteHeader(http.StatusBadGateway)
+	}))
+
+	client := New(srv.URL)
+	cur := client.NewActionsCursor(ActionsRequest{}, ActionsCursorOptions{})
+
+	assert.False(t, cur.Next(context.Background()))
+	assert.Equal(t, HTTPError{Code: 502}, cur.Err())
+	assert.False(t, cur.Next(context.Background()))
+}
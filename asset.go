@@ -0,0 +1,248 @@
+package leapapi
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Max precision a symbol can have.
+const MaxSymbolPrecision = 18
+
+// Max absolute amount an asset can have (same as the chain).
+const MaxAssetAmount = int64(1<<62 - 1)
+
+var (
+	ErrAssetSymbolMismatch = errors.New("asset: symbol mismatch")
+	ErrAssetOutOfRange     = errors.New("asset: amount out of range")
+	ErrAssetDivideByZero   = errors.New("asset: divide by zero")
+)
+
+// Token symbol, precision and code (for example 4,EOS)
+type Symbol struct {
+	Precision uint8
+	Code      string
+}
+
+func validSymbolCode(code string) bool {
+	if len(code) < 1 || len(code) > 7 {
+		return false
+	}
+
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseSymbol parses a symbol in "<precision>,<code>" format.
+func ParseSymbol(s string) (Symbol, error) {
+	parts := strings.SplitN(s, ",", 2)
+	if len(parts) != 2 {
+		return Symbol{}, fmt.Errorf("symbol: invalid format %q", s)
+	}
+
+	p, err := strconv.ParseUint(parts[0], 10, 8)
+	if err != nil || p > MaxSymbolPrecision {
+		return Symbol{}, fmt.Errorf("symbol: invalid precision %q", parts[0])
+	}
+
+	if !validSymbolCode(parts[1]) {
+		return Symbol{}, fmt.Errorf("symbol: invalid code %q", parts[1])
+	}
+
+	return Symbol{Precision: uint8(p), Code: parts[1]}, nil
+}
+
+func (s Symbol) String() string {
+	return fmt.Sprintf("%d,%s", s.Precision, s.Code)
+}
+
+func (s Symbol) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+func (s *Symbol) UnmarshalJSON(b []byte) error {
+	var str string
+
+	err := json.Unmarshal(b, &str)
+	if err != nil {
+		return err
+	}
+
+	*s, err = ParseSymbol(str)
+	return err
+}
+
+// Asset (for example "1.0000 EOS")
+type Asset struct {
+	Amount int64
+	Symbol Symbol
+}
+
+// ParseAsset parses an asset in "<amount> <code>" format.
+// The precision of the symbol is the number of decimals in amount.
+func ParseAsset(s string) (Asset, error) {
+	parts := strings.SplitN(strings.TrimSpace(s), " ", 2)
+	if len(parts) != 2 {
+		return Asset{}, fmt.Errorf("asset: invalid format %q", s)
+	}
+
+	amount, code := parts[0], strings.TrimSpace(parts[1])
+	if !validSymbolCode(code) {
+		return Asset{}, fmt.Errorf("asset: invalid symbol code %q", code)
+	}
+
+	// Only a "-" sign is allowed (same as the chain).
+	if strings.HasPrefix(amount, "+") {
+		return Asset{}, fmt.Errorf("asset: invalid amount %q", amount)
+	}
+
+	precision := 0
+	if i := strings.IndexByte(amount, '.'); i >= 0 {
+		precision = len(amount) - i - 1
+		if precision < 1 || len(strings.TrimPrefix(amount[:i], "-")) < 1 {
+			return Asset{}, fmt.Errorf("asset: invalid amount %q", amount)
+		}
+		amount = amount[:i] + amount[i+1:]
+	}
+
+	if precision > MaxSymbolPrecision {
+		return Asset{}, fmt.Errorf("asset: precision %d is too large", precision)
+	}
+
+	v, err := strconv.ParseInt(amount, 10, 64)
+	if err != nil {
+		return Asset{}, fmt.Errorf("asset: invalid amount %q", parts[0])
+	}
+
+	if v > MaxAssetAmount || v < -MaxAssetAmount {
+		return Asset{}, ErrAssetOutOfRange
+	}
+
+	return Asset{Amount: v, Symbol: Symbol{Precision: uint8(precision), Code: code}}, nil
+}
+
+func (a Asset) String() string {
+	sign := ""
+	v := uint64(a.Amount)
+	if a.Amount < 0 {
+		sign = "-"
+		v = uint64(-a.Amount)
+	}
+
+	s := strconv.FormatUint(v, 10)
+	if p := int(a.Symbol.Precision); p > 0 {
+		if len(s) <= p {
+			s = strings.Repeat("0", p-len(s)+1) + s
+		}
+		s = s[:len(s)-p] + "." + s[len(s)-p:]
+	}
+
+	return sign + s + " " + a.Symbol.Code
+}
+
+func (a Asset) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.String())
+}
+
+func (a *Asset) UnmarshalJSON(b []byte) error {
+	var str string
+
+	err := json.Unmarshal(b, &str)
+	if err != nil {
+		return err
+	}
+
+	*a, err = ParseAsset(str)
+	return err
+}
+
+func assetAmountInRange(v int64) bool {
+	return -MaxAssetAmount <= v && v <= MaxAssetAmount
+}
+
+func (a Asset) result(v int64) (Asset, error) {
+	if !assetAmountInRange(v) {
+		return Asset{}, ErrAssetOutOfRange
+	}
+	return Asset{Amount: v, Symbol: a.Symbol}, nil
+}
+
+// Add returns a + b.
+// Both assets must have the same symbol.
+func (a Asset) Add(b Asset) (Asset, error) {
+	if a.Symbol != b.Symbol {
+		return Asset{}, ErrAssetSymbolMismatch
+	}
+
+	if !assetAmountInRange(a.Amount) || !assetAmountInRange(b.Amount) {
+		return Asset{}, ErrAssetOutOfRange
+	}
+	return a.result(a.Amount + b.Amount)
+}
+
+// Sub returns a - b.
+// Both assets must have the same symbol.
+func (a Asset) Sub(b Asset) (Asset, error) {
+	if a.Symbol != b.Symbol {
+		return Asset{}, ErrAssetSymbolMismatch
+	}
+
+	if !assetAmountInRange(a.Amount) || !assetAmountInRange(b.Amount) {
+		return Asset{}, ErrAssetOutOfRange
+	}
+	return a.result(a.Amount - b.Amount)
+}
+
+// Mul returns a * n.
+func (a Asset) Mul(n int64) (Asset, error) {
+	v := new(big.Int).Mul(big.NewInt(a.Amount), big.NewInt(n))
+	if !v.IsInt64() {
+		return Asset{}, ErrAssetOutOfRange
+	}
+	return a.result(v.Int64())
+}
+
+// Div returns a / n truncated towards zero.
+func (a Asset) Div(n int64) (Asset, error) {
+	if n == 0 {
+		return Asset{}, ErrAssetDivideByZero
+	}
+
+	v := new(big.Int).Quo(big.NewInt(a.Amount), big.NewInt(n))
+	if !v.IsInt64() {
+		return Asset{}, ErrAssetOutOfRange
+	}
+	return a.result(v.Int64())
+}
+
+// Neg returns -a.
+func (a Asset) Neg() Asset {
+	return Asset{Amount: -a.Amount, Symbol: a.Symbol}
+}
+
+// Cmp compares a and b and returns -1 if a < b, 0 if a == b
+// and +1 if a > b. Both assets must have the same symbol.
+func (a Asset) Cmp(b Asset) (int, error) {
+	if a.Symbol != b.Symbol {
+		return 0, ErrAssetSymbolMismatch
+	}
+
+	switch {
+	case a.Amount < b.Amount:
+		return -1, nil
+	case a.Amount > b.Amount:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// IsZero returns true if the amount is zero.
+func (a Asset) IsZero() bool {
+	return a.Amount == 0
+}
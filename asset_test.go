@@ -0,0 +1,163 @@
+package leapapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAsset(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Asset
+		wantErr bool
+	}{
+		{name: "precision 4", input: "1.0000 EOS", want: Asset{Amount: 10000, Symbol: Symbol{Precision: 4, Code: "EOS"}}},
+		{name: "precision 0", input: "42 TKN", want: Asset{Amount: 42, Symbol: Symbol{Precision: 0, Code: "TKN"}}},
+		{name: "negative", input: "-0.0010 WAX", want: Asset{Amount: -10, Symbol: Symbol{Precision: 4, Code: "WAX"}}},
+		{name: "max amount", input: "461168601842738.7903 EOS", want: Asset{Amount: 4611686018427387903, Symbol: Symbol{Precision: 4, Code: "EOS"}}},
+		{name: "min amount", input: "-461168601842738.7903 EOS", want: Asset{Amount: -4611686018427387903, Symbol: Symbol{Precision: 4, Code: "EOS"}}},
+		{name: "missing symbol", input: "1.0000", wantErr: true},
+		{name: "lowercase symbol", input: "1.0000 eos", wantErr: true},
+		{name: "symbol too long", input: "1.0000 ABCDEFGH", wantErr: true},
+		{name: "trailing dot", input: "1. EOS", wantErr: true},
+		{name: "leading dot", input: ".5 EOS", wantErr: true},
+		{name: "negative leading dot", input: "-.5 EOS", wantErr: true},
+		{name: "plus sign", input: "+1.0000 EOS", wantErr: true},
+		{name: "plus sign leading dot", input: "+.5 EOS", wantErr: true},
+		{name: "invalid amount", input: "1.00a0 EOS", wantErr: true},
+		{name: "above max amount", input: "461168601842738.7904 EOS", wantErr: true},
+		{name: "below min amount", input: "-461168601842738.7904 EOS", wantErr: true},
+		{name: "overflow", input: "92233720368547758.08 EOS", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAsset(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.input, got.String())
+		})
+	}
+}
+
+func TestAsset_String(t *testing.T) {
+	tests := []struct {
+		name  string
+		asset Asset
+		want  string
+	}{
+		{name: "zero", asset: Asset{Symbol: Symbol{Precision: 4, Code: "EOS"}}, want: "0.0000 EOS"},
+		{name: "small", asset: Asset{Amount: 1, Symbol: Symbol{Precision: 8, Code: "BTC"}}, want: "0.00000001 BTC"},
+		{name: "negative small", asset: Asset{Amount: -5, Symbol: Symbol{Precision: 2, Code: "USD"}}, want: "-0.05 USD"},
+		{name: "min int64", asset: Asset{Amount: -9223372036854775808, Symbol: Symbol{Precision: 4, Code: "EOS"}}, want: "-922337203685477.5808 EOS"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.asset.String())
+		})
+	}
+}
+
+func TestParseSymbol(t *testing.T) {
+	s, err := ParseSymbol("4,EOS")
+	require.NoError(t, err)
+	assert.Equal(t, Symbol{Precision: 4, Code: "EOS"}, s)
+	assert.Equal(t, "4,EOS", s.String())
+
+	_, err = ParseSymbol("19,EOS")
+	assert.Error(t, err)
+
+	_, err = ParseSymbol("EOS")
+	assert.Error(t, err)
+}
+
+func TestAsset_Arithmetic(t *testing.T) {
+	eos := Symbol{Precision: 4, Code: "EOS"}
+	a := Asset{Amount: 15000, Symbol: eos}
+	b := Asset{Amount: 2500, Symbol: eos}
+
+	r, err := a.Add(b)
+	require.NoError(t, err)
+	assert.Equal(t, "1.7500 EOS", r.String())
+
+	r, err = b.Sub(a)
+	require.NoError(t, err)
+	assert.Equal(t, "-1.2500 EOS", r.String())
+
+	r, err = a.Mul(3)
+	require.NoError(t, err)
+	assert.Equal(t, "4.5000 EOS", r.String())
+
+	r, err = a.Div(4)
+	require.NoError(t, err)
+	assert.Equal(t, "0.3750 EOS", r.String())
+
+	assert.Equal(t, "-1.5000 EOS", a.Neg().String())
+
+	c, err := a.Cmp(b)
+	require.NoError(t, err)
+	assert.Equal(t, 1, c)
+
+	c, err = b.Cmp(a)
+	require.NoError(t, err)
+	assert.Equal(t, -1, c)
+
+	c, err = a.Cmp(a)
+	require.NoError(t, err)
+	assert.Equal(t, 0, c)
+
+	assert.True(t, Asset{Symbol: eos}.IsZero())
+	assert.False(t, a.IsZero())
+}
+
+func TestAsset_ArithmeticErrors(t *testing.T) {
+	eos := Asset{Amount: 1, Symbol: Symbol{Precision: 4, Code: "EOS"}}
+	wax := Asset{Amount: 1, Symbol: Symbol{Precision: 8, Code: "WAX"}}
+	max := Asset{Amount: MaxAssetAmount, Symbol: eos.Symbol}
+
+	_, err := eos.Add(wax)
+	assert.Equal(t, ErrAssetSymbolMismatch, err)
+
+	_, err = eos.Sub(wax)
+	assert.Equal(t, ErrAssetSymbolMismatch, err)
+
+	_, err = eos.Cmp(wax)
+	assert.Equal(t, ErrAssetSymbolMismatch, err)
+
+	_, err = max.Add(eos)
+	assert.Equal(t, ErrAssetOutOfRange, err)
+
+	_, err = max.Neg().Sub(eos)
+	assert.Equal(t, ErrAssetOutOfRange, err)
+
+	_, err = max.Mul(2)
+	assert.Equal(t, ErrAssetOutOfRange, err)
+
+	_, err = max.Mul(9223372036854775807)
+	assert.Equal(t, ErrAssetOutOfRange, err)
+
+	_, err = eos.Div(0)
+	assert.Equal(t, ErrAssetDivideByZero, err)
+}
+
+func TestAsset_Json(t *testing.T) {
+	var a Asset
+	err := json.Unmarshal([]byte(`"12.3400 EOS"`), &a)
+	require.NoError(t, err)
+	assert.Equal(t, Asset{Amount: 123400, Symbol: Symbol{Precision: 4, Code: "EOS"}}, a)
+
+	payload, err := json.Marshal(a)
+	require.NoError(t, err)
+	assert.Equal(t, `"12.3400 EOS"`, string(payload))
+
+	err = json.Unmarshal([]byte(`"invalid"`), &a)
+	assert.Error(t, err)
+}
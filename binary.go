@@ -0,0 +1,198 @@
+package leapapi
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+)
+
+var errVarUint32Overflow = errors.New("varuint32 overflow")
+
+// encoder writes values in the Antelope binary format (little endian).
+type encoder struct {
+	buf bytes.Buffer
+}
+
+func (e *encoder) Bytes() []byte {
+	return e.buf.Bytes()
+}
+
+func (e *encoder) writeBytes(b []byte) {
+	e.buf.Write(b)
+}
+
+func (e *encoder) writeUint8(v uint8) {
+	e.buf.WriteByte(v)
+}
+
+func (e *encoder) writeBool(v bool) {
+	if v {
+		e.writeUint8(1)
+	} else {
+		e.writeUint8(0)
+	}
+}
+
+func (e *encoder) writeUint16(v uint16) {
+	var b [2]byte
+	binary.LittleEndian.PutUint16(b[:], v)
+	e.buf.Write(b[:])
+}
+
+func (e *encoder) writeUint32(v uint32) {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], v)
+	e.buf.Write(b[:])
+}
+
+func (e *encoder) writeUint64(v uint64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], v)
+	e.buf.Write(b[:])
+}
+
+func (e *encoder) writeFloat32(v float32) {
+	e.writeUint32(math.Float32bits(v))
+}
+
+func (e *encoder) writeFloat64(v float64) {
+	e.writeUint64(math.Float64bits(v))
+}
+
+func (e *encoder) writeVarUint32(v uint32) {
+	for {
+		b := uint8(v & 0x7f)
+		v >>= 7
+		if v > 0 {
+			b |= 0x80
+		}
+		e.writeUint8(b)
+		if v == 0 {
+			break
+		}
+	}
+}
+
+func (e *encoder) writeVarInt32(v int32) {
+	// zigzag encoding
+	e.writeVarUint32(uint32((v << 1) ^ (v >> 31)))
+}
+
+// Length prefixed byte array
+func (e *encoder) writeByteArray(b []byte) {
+	e.writeVarUint32(uint32(len(b)))
+	e.writeBytes(b)
+}
+
+func (e *encoder) writeString(s string) {
+	e.writeByteArray([]byte(s))
+}
+
+// decoder reads values in the Antelope binary format (little endian).
+type decoder struct {
+	data []byte
+	pos  int
+}
+
+func newDecoder(data []byte) *decoder {
+	return &decoder{data: data}
+}
+
+func (d *decoder) remaining() int {
+	return len(d.data) - d.pos
+}
+
+func (d *decoder) readBytes(n int) ([]byte, error) {
+	if n < 0 || d.remaining() < n {
+		return nil, io.ErrUnexpectedEOF
+	}
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+func (d *decoder) readUint8() (uint8, error) {
+	b, err := d.readBytes(1)
+	if err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+func (d *decoder) readBool() (bool, error) {
+	v, err := d.readUint8()
+	return v != 0, err
+}
+
+func (d *decoder) readUint16() (uint16, error) {
+	b, err := d.readBytes(2)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint16(b), nil
+}
+
+func (d *decoder) readUint32() (uint32, error) {
+	b, err := d.readBytes(4)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(b), nil
+}
+
+func (d *decoder) readUint64() (uint64, error) {
+	b, err := d.readBytes(8)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint64(b), nil
+}
+
+func (d *decoder) readFloat32() (float32, error) {
+	v, err := d.readUint32()
+	return math.Float32frombits(v), err
+}
+
+func (d *decoder) readFloat64() (float64, error) {
+	v, err := d.readUint64()
+	return math.Float64frombits(v), err
+}
+
+func (d *decoder) readVarUint32() (uint32, error) {
+	var v uint64
+	for shift := uint(0); shift < 35; shift += 7 {
+		b, err := d.readUint8()
+		if err != nil {
+			return 0, err
+		}
+
+		v |= uint64(b&0x7f) << shift
+		if b&0x80 == 0 {
+			if v > math.MaxUint32 {
+				break
+			}
+			return uint32(v), nil
+		}
+	}
+	return 0, errVarUint32Overflow
+}
+
+func (d *decoder) readVarInt32() (int32, error) {
+	v, err := d.readVarUint32()
+	return int32(v>>1) ^ -int32(v&1), err
+}
+
+func (d *decoder) readByteArray() ([]byte, error) {
+	n, err := d.readVarUint32()
+	if err != nil {
+		return nil, err
+	}
+	return d.readBytes(int(n))
+}
+
+func (d *decoder) readString() (string, error) {
+	b, err := d.readByteArray()
+	return string(b), err
+}
@@ -0,0 +1,61 @@
+package leapapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBinary_VarUint32(t *testing.T) {
+	tests := []struct {
+		value    uint32
+		expected []byte
+	}{
+		{0, []byte{0x00}},
+		{127, []byte{0x7f}},
+		{128, []byte{0x80, 0x01}},
+		{300, []byte{0xac, 0x02}},
+		{0xffffffff, []byte{0xff, 0xff, 0xff, 0xff, 0x0f}},
+	}
+
+	for _, test := range tests {
+		e := &encoder{}
+		e.writeVarUint32(test.value)
+		assert.Equal(t, test.expected, e.Bytes())
+
+		v, err := newDecoder(test.expected).readVarUint32()
+		require.NoError(t, err)
+		assert.Equal(t, test.value, v)
+	}
+
+	_, err := newDecoder([]byte{0xff, 0xff, 0xff, 0xff, 0x1f}).readVarUint32()
+	assert.Equal(t, errVarUint32Overflow, err)
+}
+
+func TestBinary_VarInt32(t *testing.T) {
+	for _, v := range []int32{0, 1, -1, -5, 1 << 30, -1 << 31} {
+		e := &encoder{}
+		e.writeVarInt32(v)
+
+		out, err := newDecoder(e.Bytes()).readVarInt32()
+		require.NoError(t, err)
+		assert.Equal(t, v, out)
+	}
+}
+
+func TestKeyEncoding_PublicKey(t *testing.T) {
+	legacy := "EOS6MRyAjQq8ud7hVNYcfnVPJqcVpscN5So8BhtHuGYqET5GDW5CV"
+
+	typ, data, err := decodePublicKeyString(legacy)
+	require.NoError(t, err)
+	assert.Equal(t, KeyTypeK1, typ)
+	assert.Len(t, data, 33)
+	assert.Equal(t, legacy, encodePublicKeyString(typ, data))
+
+	_, _, err = decodePublicKeyString("EOS6MRyAjQq8ud7hVNYcfnVPJqcVpscN5So8BhtHuGYqET5GDW5CW")
+	assert.EqualError(t, err, "public key: checksum mismatch")
+
+	_, _, err = decodePublicKeyString("PUB_XX_abc")
+	assert.EqualError(t, err, `public key: unknown key type "XX"`)
+}
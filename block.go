@@ -0,0 +1,22 @@
+package leapapi
+
+import "time"
+
+// get_block format
+type Block struct {
+	Timestamp         time.Time               `json:"timestamp" time_format:"2006-01-02T15:04:05.000"`
+	Producer          Name                    `json:"producer"`
+	Confirmed         uint16                  `json:"confirmed"`
+	Previous          string                  `json:"previous"`
+	TransactionMRoot  string                  `json:"transaction_mroot"`
+	ActionMRoot       string                  `json:"action_mroot"`
+	ScheduleVersion   uint32                  `json:"schedule_version"`
+	NewProducers      *LegacyProducerSchedule `json:"new_producers"`
+	HeaderExtensions  []Extension             `json:"header_extensions"`
+	ProducerSignature string                  `json:"producer_signature"`
+	Transactions      []TransactionReceipt    `json:"transactions"`
+	BlockExtensions   []Extension             `json:"block_extensions"`
+	ID                string                  `json:"id"`
+	BlockNum          int64                   `json:"block_num"`
+	RefBlockPrefix    uint32                  `json:"ref_block_prefix"`
+}
@@ -0,0 +1,94 @@
+package leapapi
+
+import (
+	"fmt"
+	"time"
+)
+
+// Block header format
+type BlockHeader struct {
+	Timestamp         time.Time               `json:"timestamp" time_format:"2006-01-02T15:04:05.000"`
+	Producer          Name                    `json:"producer"`
+	Confirmed         uint16                  `json:"confirmed"`
+	Previous          string                  `json:"previous"`
+	TransactionMRoot  string                  `json:"transaction_mroot"`
+	ActionMRoot       string                  `json:"action_mroot"`
+	ScheduleVersion   uint32                  `json:"schedule_version"`
+	NewProducers      *LegacyProducerSchedule `json:"new_producers,omitempty"`
+	HeaderExtensions  []Extension             `json:"header_extensions"`
+	ProducerSignature string                  `json:"producer_signature"`
+}
+
+// Incremental merkle tree
+type IncrementalMerkle struct {
+	ActiveNodes []string `json:"_active_nodes"`
+	NodeCount   uint64   `json:"_node_count"`
+}
+
+// Pending producer schedule
+type PendingSchedule struct {
+	ScheduleLIBNum uint32                    `json:"schedule_lib_num"`
+	ScheduleHash   string                    `json:"schedule_hash"`
+	Schedule       ProducerAuthoritySchedule `json:"schedule"`
+}
+
+// Protocol features activated at a block
+type ActivatedProtocolFeatureSet struct {
+	ProtocolFeatures []string `json:"protocol_features"`
+}
+
+// Producer and block number pair. Encoded as a [producer, block_num]
+// pair in json.
+type ProducerBlockNum struct {
+	Producer Name
+	BlockNum uint32
+}
+
+func (p ProducerBlockNum) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]interface{}{p.Producer, p.BlockNum})
+}
+
+func (p *ProducerBlockNum) UnmarshalJSON(b []byte) error {
+	var r []interface{}
+
+	err := json.Unmarshal(b, &r)
+	if err != nil {
+		return err
+	}
+
+	if len(r) != 2 {
+		return fmt.Errorf("producer block num: expected 2 elements, got %d", len(r))
+	}
+
+	name, ok := r[0].(string)
+	if !ok {
+		return fmt.Errorf("producer block num: invalid producer %v", r[0])
+	}
+
+	num, ok := r[1].(float64)
+	if !ok {
+		return fmt.Errorf("producer block num: invalid block number %v", r[1])
+	}
+
+	p.Producer = Name(name)
+	p.BlockNum = uint32(num)
+	return nil
+}
+
+// get_block_header_state format
+type BlockHeaderState struct {
+	ID                               string                       `json:"id"`
+	BlockNum                         int64                        `json:"block_num"`
+	Header                           BlockHeader                  `json:"header"`
+	DPoSProposedIrreversibleBlockNum int64                        `json:"dpos_proposed_irreversible_blocknum"`
+	DPoSIrreversibleBlockNum         int64                        `json:"dpos_irreversible_blocknum"`
+	ActiveSchedule                   ProducerAuthoritySchedule    `json:"active_schedule"`
+	BlockrootMerkle                  IncrementalMerkle            `json:"blockroot_merkle"`
+	ProducerToLastProduced           []ProducerBlockNum           `json:"producer_to_last_produced"`
+	ProducerToLastImpliedIRB         []ProducerBlockNum           `json:"producer_to_last_implied_irb"`
+	ValidBlockSigningAuthority       BlockSigningAuthority        `json:"valid_block_signing_authority"`
+	ConfirmCount                     []uint8                      `json:"confirm_count"`
+	PendingSchedule                  PendingSchedule              `json:"pending_schedule"`
+	ActivatedProtocolFeatures        *ActivatedProtocolFeatureSet `json:"activated_protocol_features"`
+	AdditionalSignatures             []string                     `json:"additional_signatures"`
+}
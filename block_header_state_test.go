@@ -0,0 +1,109 @@
+package leapapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockHeaderState_JsonDecode(t *testing.T) {
+	payload := `{
+  "id": "0e7cfbbe8a6c6cf5b6d7a0cbb97a5c6b3fbe3fe11a4a2ad1d5ef1b2c3d4e5f60",
+  "header": {
+    "timestamp": "2023-01-17T09:41:23.500",
+    "producer": "eosnationftw",
+    "confirmed": 0,
+    "previous": "0e7cfbbdd0fbd38e6f3e0d3a4cf3a9d6b1d5e1bd1ef5d8f44b3a8bd52a4d6e2b",
+    "transaction_mroot": "0000000000000000000000000000000000000000000000000000000000000000",
+    "action_mroot": "b6e4f1d2f0c4fa2a1bcd3e0c1f0d3c0f6aa1b2c3d4e5f60718293a4b5c6d7e8f",
+    "schedule_version": 2167,
+    "header_extensions": [],
+    "producer_signature": "SIG_K1_KZ3Q8pWwoLLtWDNxSmBsfuq6VEMjfE4xtKRsuNRbP2fHGy8uYHxu5qtHcJT3TZL1DDURWkU1Gy1F4FDVyT7f7BsXGyowrR"
+  },
+  "block_num": 243071934,
+  "dpos_proposed_irreversible_blocknum": 243071770,
+  "dpos_irreversible_blocknum": 243071602,
+  "active_schedule": {
+    "version": 2167,
+    "producers": [
+      {
+        "producer_name": "aus1genereos",
+        "authority": [0, {"threshold": 1, "keys": [{"key": "EOS6zSgKm6pFyHVPX5a1t5GEU4aQkFR6DKFoTP7cHmbmWGs1EfKBH", "weight": 1}]}]
+      }
+    ]
+  },
+  "blockroot_merkle": {
+    "_active_nodes": ["1a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d5e6f708192a3b4c5d6e7f809"],
+    "_node_count": 243071933
+  },
+  "producer_to_last_produced": [["aus1genereos", 243071689], ["eosnationftw", 243071934]],
+  "producer_to_last_implied_irb": [["eosnationftw", 243071770]],
+  "valid_block_signing_authority": [0, {"threshold": 1, "keys": [{"key": "EOS7EarnUhcyYqmdnPon8rm7mBCTnBoot6o7fE2WzjvEX2TdggbL3", "weight": 1}]}],
+  "confirm_count": [1, 2, 3],
+  "pending_schedule": {
+    "schedule_lib_num": 243000000,
+    "schedule_hash": "4a2b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b",
+    "schedule": {"version": 2167, "producers": []}
+  },
+  "activated_protocol_features": {
+    "protocol_features": ["0ec7e080177b2c02b278d5088611686b49d739925a92d9bfcacd7fc6b74053bd"]
+  },
+  "additional_signatures": []
+}`
+
+	var state BlockHeaderState
+	err := json.Unmarshal([]byte(payload), &state)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(243071934), state.BlockNum)
+	assert.Equal(t, int64(243071770), state.DPoSProposedIrreversibleBlockNum)
+	assert.Equal(t, int64(243071602), state.DPoSIrreversibleBlockNum)
+	assert.Equal(t, time.Date(2023, 1, 17, 9, 41, 23, 500000000, time.UTC), state.Header.Timestamp)
+	assert.Equal(t, Name("eosnationftw"), state.Header.Producer)
+
+	assert.Equal(t, ProducerAuthoritySchedule{
+		Version: 2167,
+		Producers: []ProducerAuthority{
+			{
+				ProducerName: "aus1genereos",
+				Authority: BlockSigningAuthority{
+					Threshold: 1,
+					Keys:      []KeyWeight{{Key: mustParsePublicKey("EOS6zSgKm6pFyHVPX5a1t5GEU4aQkFR6DKFoTP7cHmbmWGs1EfKBH"), Weight: 1}},
+				},
+			},
+		},
+	}, state.ActiveSchedule)
+
+	assert.Equal(t, uint64(243071933), state.BlockrootMerkle.NodeCount)
+	assert.Equal(t, []ProducerBlockNum{
+		{Producer: "aus1genereos", BlockNum: 243071689},
+		{Producer: "eosnationftw", BlockNum: 243071934},
+	}, state.ProducerToLastProduced)
+	assert.Equal(t, []ProducerBlockNum{{Producer: "eosnationftw", BlockNum: 243071770}}, state.ProducerToLastImpliedIRB)
+	assert.Equal(t, uint32(1), state.ValidBlockSigningAuthority.Threshold)
+	assert.Equal(t, []uint8{1, 2, 3}, state.ConfirmCount)
+	assert.Equal(t, uint32(243000000), state.PendingSchedule.ScheduleLIBNum)
+	assert.Equal(t, uint32(2167), state.PendingSchedule.Schedule.Version)
+	require.NotNil(t, state.ActivatedProtocolFeatures)
+	assert.Len(t, state.ActivatedProtocolFeatures.ProtocolFeatures, 1)
+}
+
+func TestBlockSigningAuthority_JsonEncode(t *testing.T) {
+	auth := BlockSigningAuthority{
+		Threshold: 1,
+		Keys:      []KeyWeight{{Key: mustParsePublicKey("EOS6zSgKm6pFyHVPX5a1t5GEU4aQkFR6DKFoTP7cHmbmWGs1EfKBH"), Weight: 1}},
+	}
+
+	payload, err := json.Marshal(auth)
+	require.NoError(t, err)
+	assert.Equal(t, `[0,{"threshold":1,"keys":[{"key":"EOS6zSgKm6pFyHVPX5a1t5GEU4aQkFR6DKFoTP7cHmbmWGs1EfKBH","weight":1}]}]`, string(payload))
+}
+
+func TestBlockSigningAuthority_JsonDecodeUnknownType(t *testing.T) {
+	var auth BlockSigningAuthority
+	err := json.Unmarshal([]byte(`[1, {"threshold": 1, "keys": []}]`), &auth)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "block signing authority: unknown type 1")
+}
@@ -0,0 +1,19 @@
+package leapapi
+
+import "time"
+
+// get_block_info format
+type BlockInfo struct {
+	BlockNum          int64     `json:"block_num"`
+	RefBlockNum       uint16    `json:"ref_block_num"`
+	ID                string    `json:"id"`
+	Timestamp         time.Time `json:"timestamp" time_format:"2006-01-02T15:04:05.000"`
+	Producer          Name      `json:"producer"`
+	Confirmed         uint16    `json:"confirmed"`
+	Previous          string    `json:"previous"`
+	TransactionMRoot  string    `json:"transaction_mroot"`
+	ActionMRoot       string    `json:"action_mroot"`
+	ScheduleVersion   uint32    `json:"schedule_version"`
+	ProducerSignature string    `json:"producer_signature"`
+	RefBlockPrefix    uint32    `json:"ref_block_prefix"`
+}
@@ -0,0 +1,127 @@
+package leapapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlock_JsonDecode(t *testing.T) {
+	payload := `{
+  "timestamp": "2023-01-17T09:41:23.500",
+  "producer": "eosnationftw",
+  "confirmed": 0,
+  "previous": "0e7cfbbdd0fbd38e6f3e0d3a4cf3a9d6b1d5e1bd1ef5d8f44b3a8bd52a4d6e2b",
+  "transaction_mroot": "3c1f4bbeb9c2e9d1f4a4f5e0ab4a2a06d5c93e2c9c03d14e3f4f1f8fbb1a2b3c",
+  "action_mroot": "b6e4f1d2f0c4fa2a1bcd3e0c1f0d3c0f6aa1b2c3d4e5f60718293a4b5c6d7e8f",
+  "schedule_version": 2167,
+  "new_producers": {
+    "version": 2168,
+    "producers": [
+      {"producer_name": "aus1genereos", "block_signing_key": "EOS6zSgKm6pFyHVPX5a1t5GEU4aQkFR6DKFoTP7cHmbmWGs1EfKBH"}
+    ]
+  },
+  "header_extensions": [],
+  "producer_signature": "SIG_K1_KZ3Q8pWwoLLtWDNxSmBsfuq6VEMjfE4xtKRsuNRbP2fHGy8uYHxu5qtHcJT3TZL1DDURWkU1Gy1F4FDVyT7f7BsXGyowrR",
+  "transactions": [
+    {
+      "status": "executed",
+      "cpu_usage_us": 175,
+      "net_usage_words": 16,
+      "trx": {
+        "id": "a2f5e6a1b0b52b1d8c4bd30cf2ba7a2fa2e91c3b0f4a0a3d9c1d0e3f2b9a8c7d",
+        "signatures": ["SIG_K1_K8f9vF8jVaD4S5g6YZyBkPPfz3V3H4fBU3Q3kvzWgMMCjG8JY1ajg8N7Kw6BqNzZ2vBkQpn7QAKRDH7BvyV5qYHWgHgEyC"],
+        "compression": "none",
+        "packed_context_free_data": "",
+        "context_free_data": [],
+        "packed_trx": "e36dc663fefb2d6f3e0d000000000100a6823403ea3055000000572d3ccdcd01a02e45ea52a42e4500000000a8ed32322aa02e45ea52a42e45a0a6ad5a3c12c4ac010000000000000004454f530000000009746573742074657374",
+        "transaction": {
+          "expiration": "2023-01-17T09:41:55",
+          "ref_block_num": 64510,
+          "ref_block_prefix": 3587073837,
+          "max_net_usage_words": 0,
+          "max_cpu_usage_ms": 0,
+          "delay_sec": 0,
+          "context_free_actions": [],
+          "actions": [
+            {
+              "account": "eosio.token",
+              "name": "transfer",
+              "authorization": [{"actor": "someaccount1", "permission": "active"}],
+              "data": {"from": "someaccount1", "to": "someaccount2", "quantity": "0.0001 EOS", "memo": "test test"},
+              "hex_data": "a02e45ea52a42e45a0a6ad5a3c12c4ac010000000000000004454f530000000009746573742074657374"
+            }
+          ],
+          "transaction_extensions": [[1, "0a0b"]]
+        }
+      }
+    },
+    {
+      "status": "executed",
+      "cpu_usage_us": 302,
+      "net_usage_words": 0,
+      "trx": "f1e2d3c4b5a697887766554433221100ffeeddccbbaa99887766554433221100"
+    }
+  ],
+  "block_extensions": [],
+  "id": "0e7cfbbe8a6c6cf5b6d7a0cbb97a5c6b3fbe3fe11a4a2ad1d5ef1b2c3d4e5f60",
+  "block_num": 243071934,
+  "ref_block_prefix": 3419133878
+}`
+
+	var block Block
+	err := json.Unmarshal([]byte(payload), &block)
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Date(2023, 1, 17, 9, 41, 23, 500000000, time.UTC), block.Timestamp)
+	assert.Equal(t, Name("eosnationftw"), block.Producer)
+	assert.Equal(t, uint32(2167), block.ScheduleVersion)
+	assert.Equal(t, int64(243071934), block.BlockNum)
+	assert.Equal(t, uint32(3419133878), block.RefBlockPrefix)
+
+	require.NotNil(t, block.NewProducers)
+	assert.Equal(t, uint32(2168), block.NewProducers.Version)
+	assert.Equal(t, []ProducerKey{
+		{ProducerName: "aus1genereos", BlockSigningKey: mustParsePublicKey("EOS6zSgKm6pFyHVPX5a1t5GEU4aQkFR6DKFoTP7cHmbmWGs1EfKBH")},
+	}, block.NewProducers.Producers)
+
+	require.Len(t, block.Transactions, 2)
+
+	// Packed transaction
+	receipt := block.Transactions[0]
+	assert.Equal(t, "executed", receipt.Status)
+	assert.Equal(t, uint32(175), receipt.CPUUsageUS)
+	assert.Equal(t, uint32(16), receipt.NetUsageWords)
+	assert.Equal(t, "a2f5e6a1b0b52b1d8c4bd30cf2ba7a2fa2e91c3b0f4a0a3d9c1d0e3f2b9a8c7d", receipt.Trx.ID)
+	require.NotNil(t, receipt.Trx.Packed)
+
+	trx := receipt.Trx.Packed.Transaction
+	assert.Equal(t, time.Date(2023, 1, 17, 9, 41, 55, 0, time.UTC), trx.Expiration)
+	assert.Equal(t, uint16(64510), trx.RefBlockNum)
+	assert.Equal(t, uint32(3587073837), trx.RefBlockPrefix)
+	assert.Equal(t, []Extension{{Type: 1, Data: "0a0b"}}, trx.Extensions)
+	require.Len(t, trx.Actions, 1)
+	assert.Equal(t, Name("eosio.token"), trx.Actions[0].Account)
+	assert.Equal(t, Name("transfer"), trx.Actions[0].Name)
+	assert.Equal(t, []PermissionLevel{{Actor: "someaccount1", Permission: "active"}}, trx.Actions[0].Authorization)
+	assert.Equal(t, "0.0001 EOS", trx.Actions[0].Data.(map[string]interface{})["quantity"])
+
+	// Deferred transaction (only id)
+	receipt = block.Transactions[1]
+	assert.Equal(t, uint32(302), receipt.CPUUsageUS)
+	assert.Equal(t, "f1e2d3c4b5a697887766554433221100ffeeddccbbaa99887766554433221100", receipt.Trx.ID)
+	assert.Nil(t, receipt.Trx.Packed)
+}
+
+func TestReceiptTrx_JsonEncode(t *testing.T) {
+	payload, err := json.Marshal(ReceiptTrx{ID: "abcdef"})
+	require.NoError(t, err)
+	assert.Equal(t, `"abcdef"`, string(payload))
+
+	payload, err = json.Marshal(ReceiptTrx{ID: "abcdef", Packed: &PackedTransaction{ID: "abcdef", Compression: "none"}})
+	require.NoError(t, err)
+	assert.Contains(t, string(payload), `"id":"abcdef"`)
+	assert.Contains(t, string(payload), `"compression":"none"`)
+}
@@ -2,9 +2,12 @@ package leapapi
 
 import (
 	"context"
+	"fmt"
 	"net/url"
+	"time"
 
 	"github.com/imroc/req/v3"
+	jsoniter "github.com/json-iterator/go"
 )
 
 type Client struct {
@@ -70,6 +73,588 @@ func (c *Client) GetInfo(ctx context.Context) (info Info, err error) {
 	return
 }
 
+//	GetBlock - Fetches "/v1/chain/get_block" from API
+//
+// numOrID can be either a block number or a block id.
+// ---------------------------------------------------------
+func (c *Client) GetBlock(ctx context.Context, numOrID string) (block Block, err error) {
+	body := struct {
+		NumOrID string `json:"block_num_or_id"`
+	}{numOrID}
+	err = c.send(ctx, "POST", "/v1/chain/get_block", body, &block)
+	return
+}
+
+//	GetBlockInfo - Fetches "/v1/chain/get_block_info" from API
+//
+// ---------------------------------------------------------
+func (c *Client) GetBlockInfo(ctx context.Context, num int64) (info BlockInfo, err error) {
+	body := struct {
+		Num int64 `json:"block_num"`
+	}{num}
+	err = c.send(ctx, "POST", "/v1/chain/get_block_info", body, &info)
+	return
+}
+
+//	GetBlockHeaderState - Fetches "/v1/chain/get_block_header_state" from API
+//
+// numOrID can be either a block number or a block id.
+// ---------------------------------------------------------
+func (c *Client) GetBlockHeaderState(ctx context.Context, numOrID string) (state BlockHeaderState, err error) {
+	body := struct {
+		NumOrID string `json:"block_num_or_id"`
+	}{numOrID}
+	err = c.send(ctx, "POST", "/v1/chain/get_block_header_state", body, &state)
+	return
+}
+
+type accountNameRequest struct {
+	Name string `json:"account_name"`
+}
+
+//	GetAccount - Fetches "/v1/chain/get_account" from API
+//
+// ---------------------------------------------------------
+func (c *Client) GetAccount(ctx context.Context, name string) (account Account, err error) {
+	err = c.send(ctx, "POST", "/v1/chain/get_account", accountNameRequest{name}, &account)
+	return
+}
+
+//	GetTableRows - Fetches "/v1/chain/get_table_rows" from API
+//
+// ---------------------------------------------------------
+func (c *Client) GetTableRows(ctx context.Context, req TableRowsRequest) (rows TableRows, err error) {
+	var r struct {
+		Rows    []jsoniter.RawMessage `json:"rows"`
+		More    bool                  `json:"more"`
+		NextKey string                `json:"next_key"`
+	}
+
+	err = c.send(ctx, "POST", "/v1/chain/get_table_rows", req, &r)
+	if err != nil {
+		return
+	}
+
+	rows.More = r.More
+	rows.NextKey = r.NextKey
+	rows.Rows = make([]TableRow, len(r.Rows))
+	for i, raw := range r.Rows {
+		if req.ShowPayer {
+			var p struct {
+				Data  jsoniter.RawMessage `json:"data"`
+				Payer Name                `json:"payer"`
+			}
+
+			if err = json.Unmarshal(raw, &p); err != nil {
+				return
+			}
+			rows.Rows[i] = TableRow{Data: p.Data, Payer: p.Payer}
+		} else {
+			rows.Rows[i] = TableRow{Data: raw}
+		}
+	}
+	return
+}
+
+//	GetTableByScope - Fetches "/v1/chain/get_table_by_scope" from API
+//
+// ---------------------------------------------------------
+func (c *Client) GetTableByScope(ctx context.Context, req TableByScopeRequest) (scopes TableScopes, err error) {
+	err = c.send(ctx, "POST", "/v1/chain/get_table_by_scope", req, &scopes)
+	return
+}
+
+//	GetCurrencyBalance - Fetches "/v1/chain/get_currency_balance" from API
+//
+// symbol is optional, if empty all balances for the account are returned.
+// ---------------------------------------------------------
+func (c *Client) GetCurrencyBalance(ctx context.Context, code string, account string, symbol string) (balances []Asset, err error) {
+	body := struct {
+		Code    string `json:"code"`
+		Account string `json:"account"`
+		Symbol  string `json:"symbol,omitempty"`
+	}{code, account, symbol}
+	err = c.send(ctx, "POST", "/v1/chain/get_currency_balance", body, &balances)
+	return
+}
+
+//	GetCurrencyStats - Fetches "/v1/chain/get_currency_stats" from API
+//
+// The result is keyed by symbol code.
+// ---------------------------------------------------------
+func (c *Client) GetCurrencyStats(ctx context.Context, code string, symbol string) (stats map[string]CurrencyStats, err error) {
+	body := struct {
+		Code   string `json:"code"`
+		Symbol string `json:"symbol"`
+	}{code, symbol}
+	err = c.send(ctx, "POST", "/v1/chain/get_currency_stats", body, &stats)
+	return
+}
+
+//	GetProducers - Fetches "/v1/chain/get_producers" from API
+//
+// ---------------------------------------------------------
+func (c *Client) GetProducers(ctx context.Context, req ProducersRequest) (producers Producers, err error) {
+	body := struct {
+		ProducersRequest
+		JSON bool `json:"json"`
+	}{req, true}
+	err = c.send(ctx, "POST", "/v1/chain/get_producers", body, &producers)
+	return
+}
+
+//	GetProducerSchedule - Fetches "/v1/chain/get_producer_schedule" from API
+//
+// ---------------------------------------------------------
+func (c *Client) GetProducerSchedule(ctx context.Context) (schedule ProducerSchedule, err error) {
+	err = c.send(ctx, "POST", "/v1/chain/get_producer_schedule", struct{}{}, &schedule)
+	return
+}
+
+//	GetABI - Fetches "/v1/chain/get_abi" from API
+//
+// ---------------------------------------------------------
+func (c *Client) GetABI(ctx context.Context, account string) (abi AccountABI, err error) {
+	err = c.send(ctx, "POST", "/v1/chain/get_abi", accountNameRequest{account}, &abi)
+	return
+}
+
+//	GetRawABI - Fetches "/v1/chain/get_raw_abi" from API
+//
+// ---------------------------------------------------------
+func (c *Client) GetRawABI(ctx context.Context, account string) (abi RawABI, err error) {
+	err = c.send(ctx, "POST", "/v1/chain/get_raw_abi", accountNameRequest{account}, &abi)
+	return
+}
+
+//	GetCode - Fetches "/v1/chain/get_code" from API
+//
+// ---------------------------------------------------------
+func (c *Client) GetCode(ctx context.Context, account string) (code Code, err error) {
+	body := struct {
+		Name       string `json:"account_name"`
+		CodeAsWasm bool   `json:"code_as_wasm"`
+	}{account, true}
+	err = c.send(ctx, "POST", "/v1/chain/get_code", body, &code)
+	return
+}
+
+//	GetCodeHash - Fetches "/v1/chain/get_code_hash" from API
+//
+// ---------------------------------------------------------
+func (c *Client) GetCodeHash(ctx context.Context, account string) (hash CodeHash, err error) {
+	err = c.send(ctx, "POST", "/v1/chain/get_code_hash", accountNameRequest{account}, &hash)
+	return
+}
+
+//	GetRawCodeAndABI - Fetches "/v1/chain/get_raw_code_and_abi" from API
+//
+// ---------------------------------------------------------
+func (c *Client) GetRawCodeAndABI(ctx context.Context, account string) (res RawCodeAndABI, err error) {
+	err = c.send(ctx, "POST", "/v1/chain/get_raw_code_and_abi", accountNameRequest{account}, &res)
+	return
+}
+
+//	ABIJSONToBin - Fetches "/v1/chain/abi_json_to_bin" from API
+//
+// ---------------------------------------------------------
+func (c *Client) ABIJSONToBin(ctx context.Context, req ABIJSONToBinRequest) (res ABIJSONToBinResponse, err error) {
+	err = c.send(ctx, "POST", "/v1/chain/abi_json_to_bin", req, &res)
+	return
+}
+
+//	ABIBinToJSON - Fetches "/v1/chain/abi_bin_to_json" from API
+//
+// ---------------------------------------------------------
+func (c *Client) ABIBinToJSON(ctx context.Context, req ABIBinToJSONRequest) (res ABIBinToJSONResponse, err error) {
+	err = c.send(ctx, "POST", "/v1/chain/abi_bin_to_json", req, &res)
+	return
+}
+
+// Packed transaction as sent to the api.
+type packedTransactionRequest struct {
+	Signatures            []string `json:"signatures"`
+	Compression           string   `json:"compression"`
+	PackedContextFreeData string   `json:"packed_context_free_data"`
+	PackedTrx             string   `json:"packed_trx"`
+}
+
+func newPackedTransactionRequest(tx PackedTransaction) packedTransactionRequest {
+	signatures := tx.Signatures
+	if signatures == nil {
+		signatures = []string{}
+	}
+
+	compression := tx.Compression
+	if len(compression) < 1 {
+		compression = "none"
+	}
+
+	return packedTransactionRequest{
+		Signatures:            signatures,
+		Compression:           compression,
+		PackedContextFreeData: tx.PackedContextFreeData,
+		PackedTrx:             tx.PackedTrx,
+	}
+}
+
+type transactionResponse struct {
+	TransactionID string           `json:"transaction_id"`
+	Processed     TransactionTrace `json:"processed"`
+}
+
+//	PushTransaction - Fetches "/v1/chain/push_transaction" from API
+//
+// ---------------------------------------------------------
+func (c *Client) PushTransaction(ctx context.Context, tx PackedTransaction) (trace TransactionTrace, err error) {
+	var res transactionResponse
+	err = c.send(ctx, "POST", "/v1/chain/push_transaction", newPackedTransactionRequest(tx), &res)
+	trace = res.Processed
+	return
+}
+
+//	SendTransaction - Fetches "/v1/chain/send_transaction" from API
+//
+// ---------------------------------------------------------
+func (c *Client) SendTransaction(ctx context.Context, tx PackedTransaction) (trace TransactionTrace, err error) {
+	var res transactionResponse
+	err = c.send(ctx, "POST", "/v1/chain/send_transaction", newPackedTransactionRequest(tx), &res)
+	trace = res.Processed
+	return
+}
+
+// Options for SendTransaction2
+type SendTransaction2Options struct {
+	// Return the trace of failed transactions instead of a http error.
+	// The trace is returned together with an APIError created from trace.Except.
+	ReturnFailureTrace bool
+	// Retry the transaction until it is irreversible (or RetryTrxNumBlocks is reached).
+	RetryTrx          bool
+	RetryTrxNumBlocks uint32
+}
+
+//	SendTransaction2 - Fetches "/v1/chain/send_transaction2" from API
+//
+// ---------------------------------------------------------
+func (c *Client) SendTransaction2(ctx context.Context, tx PackedTransaction, opts SendTransaction2Options) (trace TransactionTrace, err error) {
+	body := struct {
+		ReturnFailureTrace bool                     `json:"return_failure_trace"`
+		RetryTrx           bool                     `json:"retry_trx"`
+		RetryTrxNumBlocks  uint32                   `json:"retry_trx_num_blocks,omitempty"`
+		Transaction        packedTransactionRequest `json:"transaction"`
+	}{opts.ReturnFailureTrace, opts.RetryTrx, opts.RetryTrxNumBlocks, newPackedTransactionRequest(tx)}
+
+	var res transactionResponse
+	err = c.send(ctx, "POST", "/v1/chain/send_transaction2", body, &res)
+	trace = res.Processed
+	if err == nil && trace.Except != nil {
+		err = trace.Except.APIError()
+	}
+	return
+}
+
+//	ComputeTransaction - Fetches "/v1/chain/compute_transaction" from API
+//
+// The transaction is executed but not broadcasted or included in a block
+// (signatures are not required). If the transaction fails, the trace is
+// returned together with an APIError created from trace.Except.
+// ---------------------------------------------------------
+func (c *Client) ComputeTransaction(ctx context.Context, tx PackedTransaction) (trace TransactionTrace, err error) {
+	body := struct {
+		Transaction packedTransactionRequest `json:"transaction"`
+	}{newPackedTransactionRequest(tx)}
+
+	var res transactionResponse
+	err = c.send(ctx, "POST", "/v1/chain/compute_transaction", body, &res)
+	trace = res.Processed
+	if err == nil && trace.Except != nil {
+		err = trace.Except.APIError()
+	}
+	return
+}
+
+//	GetRequiredKeys - Fetches "/v1/chain/get_required_keys" from API
+//
+// Returns the subset of availableKeys that is required to sign tx.
+// ---------------------------------------------------------
+func (c *Client) GetRequiredKeys(ctx context.Context, tx Transaction, availableKeys []string) (keys []string, err error) {
+	// Send binary action data when possible so nodeos does not need the abi.
+	tx.ContextFreeActions = withHexData(tx.ContextFreeActions)
+	tx.Actions = withHexData(tx.Actions)
+
+	body := struct {
+		Transaction   Transaction `json:"transaction"`
+		AvailableKeys []string    `json:"available_keys"`
+	}{tx, availableKeys}
+
+	var res struct {
+		RequiredKeys []string `json:"required_keys"`
+	}
+	err = c.send(ctx, "POST", "/v1/chain/get_required_keys", body, &res)
+	keys = res.RequiredKeys
+	return
+}
+
+func withHexData(actions []Action) []Action {
+	out := make([]Action, len(actions))
+	for i, a := range actions {
+		if len(a.HexData) > 0 {
+			a.Data = a.HexData
+			a.HexData = ""
+		}
+		out[i] = a
+	}
+	return out
+}
+
+// Expiration used by SignAndPushTransaction when the transaction has none.
+const defaultTransactionExpiration = 30 * time.Second
+
+//	SignAndPushTransaction - Signs a transaction and pushes it to the API
+//
+// If tx has no expiration, TaPoS fields and expiration are set from get_info.
+// The required keys are selected from the keys available in provider.
+// ---------------------------------------------------------
+func (c *Client) SignAndPushTransaction(ctx context.Context, tx Transaction, provider SignatureProvider) (trace TransactionTrace, err error) {
+	info, err := c.GetInfo(ctx)
+	if err != nil {
+		return
+	}
+
+	if tx.Expiration.IsZero() {
+		if err = tx.SetTaPoS(info, defaultTransactionExpiration); err != nil {
+			return
+		}
+	}
+
+	available, err := provider.AvailableKeys(ctx)
+	if err != nil {
+		return
+	}
+
+	required, err := c.GetRequiredKeys(ctx, tx, available)
+	if err != nil {
+		return
+	}
+
+	signatures, err := provider.Sign(ctx, info.ChainID, tx, required)
+	if err != nil {
+		return
+	}
+
+	packed, err := NewPackedTransaction(tx, signatures)
+	if err != nil {
+		return
+	}
+	return c.PushTransaction(ctx, packed)
+}
+
+//	GetTransactionStatus - Fetches "/v1/chain/get_transaction_status" from API
+//
+// Requires nodeos (leap 5+) with transaction finality status enabled.
+// ---------------------------------------------------------
+func (c *Client) GetTransactionStatus(ctx context.Context, id string) (status TransactionStatus, err error) {
+	body := struct {
+		ID string `json:"id"`
+	}{id}
+
+	err = c.send(ctx, "POST", "/v1/chain/get_transaction_status", body, &status)
+	return
+}
+
+// How often WaitForTransaction polls get_transaction_status.
+var transactionStatusPollInterval = 500 * time.Millisecond
+
+//	WaitForTransaction - Polls "/v1/chain/get_transaction_status" until
+//	the transaction reaches target
+//
+// Returns the last status and ctx's error if ctx is done first.
+// An error is returned if the transaction fails, unless target is TransactionStateFailed.
+// ---------------------------------------------------------
+func (c *Client) WaitForTransaction(ctx context.Context, id string, target TransactionState) (status TransactionStatus, err error) {
+	ticker := time.NewTicker(transactionStatusPollInterval)
+	defer ticker.Stop()
+
+	for {
+		var s TransactionStatus
+		if s, err = c.GetTransactionStatus(ctx, id); err != nil {
+			return
+		}
+
+		status = s
+
+		if status.State.Reached(target) {
+			return
+		}
+
+		if status.State == TransactionStateFailed {
+			err = fmt.Errorf("transaction %s failed", id)
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+//	GetActivatedProtocolFeatures - Fetches "/v1/chain/get_activated_protocol_features" from API
+//
+// ---------------------------------------------------------
+func (c *Client) GetActivatedProtocolFeatures(ctx context.Context, req ProtocolFeaturesRequest) (features ActivatedProtocolFeatures, err error) {
+	err = c.send(ctx, "POST", "/v1/chain/get_activated_protocol_features", req, &features)
+	return
+}
+
+//	GetProtocolFeatures - Fetches all pages of "/v1/chain/get_activated_protocol_features" from API
+//
+// Useful to check chain capabilities at startup, for example:
+//
+//	features.IsActivated("ONLY_BILL_FIRST_AUTHORIZER")
+//
+// ---------------------------------------------------------
+func (c *Client) GetProtocolFeatures(ctx context.Context) (features ProtocolFeatures, err error) {
+	var list []ProtocolFeature
+
+	it := c.NewProtocolFeaturesIterator(ProtocolFeaturesRequest{})
+	for it.Next(ctx) {
+		list = append(list, it.Feature())
+	}
+
+	if err = it.Err(); err == nil {
+		features = NewProtocolFeatures(list)
+	}
+	return
+}
+
+//	GetAccountsByAuthorizers - Fetches "/v1/chain/get_accounts_by_authorizers" from API
+//
+// Accounts with an empty Permission match any permission of the actor.
+// ---------------------------------------------------------
+func (c *Client) GetAccountsByAuthorizers(ctx context.Context, accounts []PermissionLevel, keys []PublicKey) (res AccountsByAuthorizers, err error) {
+	// nodeos accepts either a plain account name or a permission level.
+	authorizers := make([]interface{}, len(accounts))
+	for i, acc := range accounts {
+		if len(acc.Permission) > 0 {
+			authorizers[i] = acc
+		} else {
+			authorizers[i] = acc.Actor
+		}
+	}
+
+	if keys == nil {
+		keys = []PublicKey{}
+	}
+
+	body := struct {
+		Accounts []interface{} `json:"accounts"`
+		Keys     []PublicKey   `json:"keys"`
+	}{authorizers, keys}
+
+	err = c.send(ctx, "POST", "/v1/chain/get_accounts_by_authorizers", body, &res)
+	return
+}
+
+//	GetActions - Fetches "/v2/history/get_actions" from API (hyperion)
+//
+// ---------------------------------------------------------
+func (c *Client) GetActions(ctx context.Context, req ActionsRequest) (actions HyperionActions, err error) {
+	err = c.send(ctx, "GET", "/v2/history/get_actions?"+req.values().Encode(), nil, &actions)
+	return
+}
+
+//	GetDeltas - Fetches "/v2/history/get_deltas" from API (hyperion)
+//
+// ---------------------------------------------------------
+func (c *Client) GetDeltas(ctx context.Context, req DeltasRequest) (deltas HyperionDeltas, err error) {
+	err = c.send(ctx, "GET", "/v2/history/get_deltas?"+req.values().Encode(), nil, &deltas)
+	return
+}
+
+//	GetTransaction - Fetches "/v2/history/get_transaction" from API (hyperion)
+//
+// ---------------------------------------------------------
+func (c *Client) GetTransaction(ctx context.Context, id string) (trx HyperionTransaction, err error) {
+	q := url.Values{"id": {id}}
+	err = c.send(ctx, "GET", "/v2/history/get_transaction?"+q.Encode(), nil, &trx)
+	return
+}
+
+//	GetCreatedAccounts - Fetches "/v2/history/get_created_accounts" from API (hyperion)
+//
+// ---------------------------------------------------------
+func (c *Client) GetCreatedAccounts(ctx context.Context, req CreatedAccountsRequest) (accounts CreatedAccounts, err error) {
+	err = c.send(ctx, "GET", "/v2/history/get_created_accounts?"+req.values().Encode(), nil, &accounts)
+	return
+}
+
+//	GetCreator - Fetches "/v2/history/get_creator" from API (hyperion)
+//
+// ---------------------------------------------------------
+func (c *Client) GetCreator(ctx context.Context, account string) (creator AccountCreator, err error) {
+	q := url.Values{"account": {account}}
+	err = c.send(ctx, "GET", "/v2/history/get_creator?"+q.Encode(), nil, &creator)
+	return
+}
+
+//	GetTokens - Fetches "/v2/state/get_tokens" from API (hyperion)
+//
+// Use NewTokensIterator to fetch all pages.
+// ---------------------------------------------------------
+func (c *Client) GetTokens(ctx context.Context, req TokensRequest) (tokens HyperionTokens, err error) {
+	err = c.send(ctx, "GET", "/v2/state/get_tokens?"+req.values().Encode(), nil, &tokens)
+	return
+}
+
+//	GetKeyAccounts - Fetches "/v2/state/get_key_accounts" from API (hyperion)
+//
+// ---------------------------------------------------------
+func (c *Client) GetKeyAccounts(ctx context.Context, key PublicKey) (accounts KeyAccounts, err error) {
+	q := url.Values{"public_key": {key.String()}}
+	err = c.send(ctx, "GET", "/v2/state/get_key_accounts?"+q.Encode(), nil, &accounts)
+	return
+}
+
+//	GetLinks - Fetches "/v2/state/get_links" from API (hyperion)
+//
+// Use NewLinksIterator to fetch all pages.
+// ---------------------------------------------------------
+func (c *Client) GetLinks(ctx context.Context, req LinksRequest) (links HyperionLinks, err error) {
+	err = c.send(ctx, "GET", "/v2/state/get_links?"+req.values().Encode(), nil, &links)
+	return
+}
+
+//	GetProposals - Fetches "/v2/state/get_proposals" from API (hyperion)
+//
+// Use NewProposalsIterator to fetch all pages.
+// ---------------------------------------------------------
+func (c *Client) GetProposals(ctx context.Context, req ProposalsRequest) (proposals HyperionProposals, err error) {
+	err = c.send(ctx, "GET", "/v2/state/get_proposals?"+req.values().Encode(), nil, &proposals)
+	return
+}
+
+//	GetVoters - Fetches "/v2/state/get_voters" from API (hyperion)
+//
+// Use NewVotersIterator to fetch all pages.
+// ---------------------------------------------------------
+func (c *Client) GetVoters(ctx context.Context, req VotersRequest) (voters HyperionVoters, err error) {
+	err = c.send(ctx, "GET", "/v2/state/get_voters?"+req.values().Encode(), nil, &voters)
+	return
+}
+
+//	GetHyperionAccount - Fetches "/v2/state/get_account" from API (hyperion)
+//
+// Returns the account together with its recent actions, tokens and links.
+// ---------------------------------------------------------
+func (c *Client) GetHyperionAccount(ctx context.Context, account string) (res HyperionAccount, err error) {
+	q := url.Values{"account": {account}}
+	err = c.send(ctx, "GET", "/v2/state/get_account?"+q.Encode(), nil, &res)
+	return
+}
+
 //	Health - Fetches "/v2/health" from API
 //
 // ---------------------------------------------------------
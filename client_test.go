@@ -25,6 +25,77 @@ var testServer = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWrite
 
 	}
 
+	if req.URL.String() == "/v1/chain/get_block" {
+		block := `{
+            "timestamp": "2018-06-08T08:08:08.500",
+            "producer": "eosio",
+            "confirmed": 0,
+            "schedule_version": 1,
+            "transactions": [],
+            "id": "00000064a1b2c3d4e5f60718293a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a",
+            "block_num": 100,
+            "ref_block_prefix": 3564286629
+        }`
+
+		_, _ = res.Write([]byte(block))
+	}
+
+	if req.URL.String() == "/v1/chain/get_block_info" {
+		info := `{
+            "block_num": 100,
+            "ref_block_num": 100,
+            "id": "00000064a1b2c3d4e5f60718293a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a",
+            "timestamp": "2018-06-08T08:08:08.000",
+            "producer": "eosio",
+            "confirmed": 1,
+            "schedule_version": 1,
+            "ref_block_prefix": 3564286629
+        }`
+
+		_, _ = res.Write([]byte(info))
+	}
+
+	if req.URL.String() == "/v1/chain/get_block_header_state" {
+		state := `{
+            "id": "00000064a1b2c3d4e5f60718293a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a",
+            "block_num": 100,
+            "dpos_proposed_irreversible_blocknum": 99,
+            "dpos_irreversible_blocknum": 98,
+            "confirm_count": []
+        }`
+
+		_, _ = res.Write([]byte(state))
+	}
+
+	if req.URL.String() == "/v1/chain/get_account" {
+		account := `{
+            "account_name": "eosio",
+            "privileged": true,
+            "created": "2018-06-08T08:08:08.500",
+            "core_liquid_balance": "1000.0000 EOS",
+            "ram_quota": -1,
+            "permissions": []
+        }`
+
+		_, _ = res.Write([]byte(account))
+	}
+
+	if req.URL.String() == "/v1/chain/get_currency_balance" {
+		_, _ = res.Write([]byte(`["1.0000 EOS", "20.50 TKN"]`))
+	}
+
+	if req.URL.String() == "/v1/chain/get_currency_stats" {
+		stats := `{
+            "EOS": {
+                "supply": "1073741824.0000 EOS",
+                "max_supply": "10000000000.0000 EOS",
+                "issuer": "eosio"
+            }
+        }`
+
+		_, _ = res.Write([]byte(stats))
+	}
+
 	if req.URL.String() == "/v2/health" {
 		info := `{
             "version": "1.0",
@@ -205,6 +276,83 @@ func TestGetInfoEmptyError(t *testing.T) {
 	require.EqualError(t, err, "server returned HTTP 401 Unauthorized")
 }
 
+func TestGetBlock(t *testing.T) {
+	client := New(testServer.URL)
+
+	block, err := client.GetBlock(context.Background(), "100")
+
+	require.NoError(t, err)
+	assert.Equal(t, Name("eosio"), block.Producer)
+	assert.Equal(t, int64(100), block.BlockNum)
+	assert.Equal(t, uint32(3564286629), block.RefBlockPrefix)
+	assert.Equal(t, time.Date(2018, 6, 8, 8, 8, 8, 500000000, time.UTC), block.Timestamp)
+	assert.Nil(t, block.NewProducers)
+	assert.Empty(t, block.Transactions)
+}
+
+func TestGetBlockInfo(t *testing.T) {
+	client := New(testServer.URL)
+
+	info, err := client.GetBlockInfo(context.Background(), 100)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), info.BlockNum)
+	assert.Equal(t, uint16(100), info.RefBlockNum)
+	assert.Equal(t, Name("eosio"), info.Producer)
+	assert.Equal(t, uint16(1), info.Confirmed)
+	assert.Equal(t, uint32(3564286629), info.RefBlockPrefix)
+	assert.Equal(t, time.Date(2018, 6, 8, 8, 8, 8, 0, time.UTC), info.Timestamp)
+}
+
+func TestGetBlockHeaderState(t *testing.T) {
+	client := New(testServer.URL)
+
+	state, err := client.GetBlockHeaderState(context.Background(), "100")
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), state.BlockNum)
+	assert.Equal(t, int64(99), state.DPoSProposedIrreversibleBlockNum)
+	assert.Equal(t, int64(98), state.DPoSIrreversibleBlockNum)
+	assert.Nil(t, state.ActivatedProtocolFeatures)
+}
+
+func TestGetAccount(t *testing.T) {
+	client := New(testServer.URL)
+
+	account, err := client.GetAccount(context.Background(), "eosio")
+
+	require.NoError(t, err)
+	assert.Equal(t, Name("eosio"), account.AccountName)
+	assert.True(t, account.Privileged)
+	assert.Equal(t, Int64(-1), account.RAMQuota)
+	require.NotNil(t, account.CoreLiquidBalance)
+	assert.Equal(t, "1000.0000 EOS", account.CoreLiquidBalance.String())
+}
+
+func TestGetCurrencyBalance(t *testing.T) {
+	client := New(testServer.URL)
+
+	balances, err := client.GetCurrencyBalance(context.Background(), "eosio.token", "someaccount1", "")
+
+	require.NoError(t, err)
+	assert.Equal(t, []Asset{
+		{Amount: 10000, Symbol: Symbol{Precision: 4, Code: "EOS"}},
+		{Amount: 2050, Symbol: Symbol{Precision: 2, Code: "TKN"}},
+	}, balances)
+}
+
+func TestGetCurrencyStats(t *testing.T) {
+	client := New(testServer.URL)
+
+	stats, err := client.GetCurrencyStats(context.Background(), "eosio.token", "EOS")
+
+	require.NoError(t, err)
+	require.Contains(t, stats, "EOS")
+	assert.Equal(t, "1073741824.0000 EOS", stats["EOS"].Supply.String())
+	assert.Equal(t, "10000000000.0000 EOS", stats["EOS"].MaxSupply.String())
+	assert.Equal(t, Name("eosio"), stats["EOS"].Issuer)
+}
+
 func TestGetHealth(t *testing.T) {
 	client := New(testServer.URL)
 
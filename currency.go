@@ -0,0 +1,8 @@
+package leapapi
+
+// get_currency_stats format (per symbol)
+type CurrencyStats struct {
+	Supply    Asset `json:"supply"`
+	MaxSupply Asset `json:"max_supply"`
+	Issuer    Name  `json:"issuer"`
+}
@@ -0,0 +1,43 @@
+package leapapi
+
+import (
+	"fmt"
+)
+
+// Block, header and transaction extension. Encoded as a
+// [type, data] pair in json where data is hex encoded.
+type Extension struct {
+	Type uint16
+	Data string
+}
+
+func (e Extension) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]interface{}{e.Type, e.Data})
+}
+
+func (e *Extension) UnmarshalJSON(b []byte) error {
+	var r []interface{}
+
+	err := json.Unmarshal(b, &r)
+	if err != nil {
+		return err
+	}
+
+	if len(r) != 2 {
+		return fmt.Errorf("extension: expected 2 elements, got %d", len(r))
+	}
+
+	t, ok := r[0].(float64)
+	if !ok {
+		return fmt.Errorf("extension: invalid type %v", r[0])
+	}
+
+	d, ok := r[1].(string)
+	if !ok {
+		return fmt.Errorf("extension: invalid data %v", r[1])
+	}
+
+	e.Type = uint16(t)
+	e.Data = d
+	return nil
+}
@@ -3,9 +3,14 @@ module github.com/eosswedenorg-go/leapapi
 go 1.16
 
 require (
+	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.0.1
 	github.com/google/go-cmp v0.5.9
+	github.com/gorilla/websocket v1.5.0
 	github.com/imroc/req/v3 v3.7.6
 	github.com/json-iterator/go v1.1.9
 	github.com/liamylian/jsontime/v2 v2.0.0
+	// Required by json-iterator, v1.0.1 panics when encoding maps on go >= 1.18.
+	github.com/modern-go/reflect2 v1.0.2 // indirect
 	github.com/stretchr/testify v1.3.0
+	golang.org/x/crypto v0.0.0-20200622213623-75b288015ac9
 )
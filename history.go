@@ -0,0 +1,359 @@
+package leapapi
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Sort order of hyperion history results.
+type SortOrder string
+
+const (
+	SortAsc  SortOrder = "asc"
+	SortDesc SortOrder = "desc"
+)
+
+// Hyperion datetime parameter format.
+const hyperionTimeFormat = "2006-01-02T15:04:05.000Z"
+
+// Contract and action filter ("code:action").
+// Action can be "*" to match all actions of the contract.
+type ActionFilter struct {
+	Code   string
+	Action string
+}
+
+func (f ActionFilter) String() string {
+	return f.Code + ":" + f.Action
+}
+
+// /v2/history/get_actions request parameters
+//
+// Zero values are not sent. AfterBlock/BeforeBlock takes precedence over After/Before.
+type ActionsRequest struct {
+	// Notified account
+	Account Name
+	// Contract/action filters
+	Filter []ActionFilter
+	// Track the exact total number of hits
+	Track bool
+	Skip  uint32
+	Limit uint32
+	Sort  SortOrder
+	// Only actions after/before this time
+	After  time.Time
+	Before time.Time
+	// Only actions after/before this block
+	AfterBlock  uint32
+	BeforeBlock uint32
+	// Return simplified actions (HyperionActions.SimpleActions)
+	Simple bool
+	// Do not include binary data
+	NoBinary bool
+	// Include the last irreversible block (HyperionActions.LIB)
+	CheckLib bool
+	// Action data terms, keyed by field (sent as "act.data.<field>")
+	Data map[string]string
+	// Arbitrary terms, keyed by field (e.g. "act.authorization.actor")
+	Terms map[string]string
+}
+
+// WithData returns a copy of r with an "act.data.<field>" term added.
+func (r ActionsRequest) WithData(field, value string) ActionsRequest {
+	data := make(map[string]string, len(r.Data)+1)
+	for k, v := range r.Data {
+		data[k] = v
+	}
+	data[field] = value
+	r.Data = data
+	return r
+}
+
+func (r ActionsRequest) values() url.Values {
+	v := url.Values{}
+
+	if len(r.Account) > 0 {
+		v.Set("account", string(r.Account))
+	}
+
+	if len(r.Filter) > 0 {
+		filters := make([]string, len(r.Filter))
+		for i, f := range r.Filter {
+			filters[i] = f.String()
+		}
+		v.Set("filter", strings.Join(filters, ","))
+	}
+
+	if r.Track {
+		v.Set("track", "true")
+	}
+
+	setSkipLimit(v, r.Skip, r.Limit)
+
+	if len(r.Sort) > 0 {
+		v.Set("sort", string(r.Sort))
+	}
+
+	if r.AfterBlock > 0 {
+		v.Set("after", strconv.FormatUint(uint64(r.AfterBlock), 10))
+	} else if !r.After.IsZero() {
+		v.Set("after", r.After.UTC().Format(hyperionTimeFormat))
+	}
+
+	if r.BeforeBlock > 0 {
+		v.Set("before", strconv.FormatUint(uint64(r.BeforeBlock), 10))
+	} else if !r.Before.IsZero() {
+		v.Set("before", r.Before.UTC().Format(hyperionTimeFormat))
+	}
+
+	if r.Simple {
+		v.Set("simple", "true")
+	}
+
+	if r.NoBinary {
+		v.Set("noBinary", "true")
+	}
+
+	if r.CheckLib {
+		v.Set("checkLib", "true")
+	}
+
+	for field, value := range r.Data {
+		v.Set("act.data."+field, value)
+	}
+
+	for field, value := range r.Terms {
+		v.Set(field, value)
+	}
+	return v
+}
+
+// Set skip/limit query parameters if not zero.
+func setSkipLimit(v url.Values, skip, limit uint32) {
+	if skip > 0 {
+		v.Set("skip", strconv.FormatUint(uint64(skip), 10))
+	}
+
+	if limit > 0 {
+		v.Set("limit", strconv.FormatUint(uint64(limit), 10))
+	}
+}
+
+// Set query parameters from a key/value map, empty values are not set.
+func setNonEmpty(v url.Values, params map[string]string) {
+	for key, value := range params {
+		if len(value) > 0 {
+			v.Set(key, value)
+		}
+	}
+}
+
+// Hyperion total hit count.
+// Relation is "eq" if Value is exact or "gte" if it is a lower bound.
+type HyperionTotal struct {
+	Value    uint64 `json:"value"`
+	Relation string `json:"relation"`
+}
+
+// Hyperion auth sequence
+type HyperionAuthSequence struct {
+	Account  Name   `json:"account"`
+	Sequence Uint64 `json:"sequence"`
+}
+
+// Hyperion action receipt
+type HyperionReceipt struct {
+	Receiver       Name                   `json:"receiver"`
+	GlobalSequence Uint64                 `json:"global_sequence"`
+	RecvSequence   Uint64                 `json:"recv_sequence"`
+	AuthSequence   []HyperionAuthSequence `json:"auth_sequence"`
+}
+
+// Hyperion action format
+type HyperionAction struct {
+	Timestamp            time.Time         `json:"@timestamp" time_format:"2006-01-02T15:04:05.000"`
+	BlockNum             uint32            `json:"block_num"`
+	BlockID              string            `json:"block_id"`
+	TrxID                string            `json:"trx_id"`
+	Act                  Action            `json:"act"`
+	Receipts             []HyperionReceipt `json:"receipts"`
+	CPUUsageUS           uint32            `json:"cpu_usage_us"`
+	NetUsageWords        uint32            `json:"net_usage_words"`
+	AccountRAMDeltas     []AccountDelta    `json:"account_ram_deltas"`
+	GlobalSequence       Uint64            `json:"global_sequence"`
+	Producer             Name              `json:"producer"`
+	ActionOrdinal        uint32            `json:"action_ordinal"`
+	CreatorActionOrdinal uint32            `json:"creator_action_ordinal"`
+	Signatures           []Signature       `json:"signatures"`
+}
+
+// Hyperion simplified action format (get_actions with simple=true)
+//
+// Actors and Notified are comma separated lists.
+type HyperionSimpleAction struct {
+	Block         uint32      `json:"block"`
+	Irreversible  bool        `json:"irreversible"`
+	Timestamp     time.Time   `json:"timestamp" time_format:"2006-01-02T15:04:05.000"`
+	TransactionID string      `json:"transaction_id"`
+	Actors        string      `json:"actors"`
+	Notified      string      `json:"notified"`
+	Contract      Name        `json:"contract"`
+	Action        Name        `json:"action"`
+	Data          interface{} `json:"data"`
+}
+
+// /v2/history/get_actions format
+type HyperionActions struct {
+	QueryTime            float32                `json:"query_time_ms"`
+	Cached               bool                   `json:"cached"`
+	LIB                  uint32                 `json:"lib"`
+	LastIndexedBlock     uint32                 `json:"last_indexed_block"`
+	LastIndexedBlockTime time.Time              `json:"last_indexed_block_time" time_format:"2006-01-02T15:04:05.000"`
+	Total                HyperionTotal          `json:"total"`
+	Actions              []HyperionAction       `json:"actions"`
+	SimpleActions        []HyperionSimpleAction `json:"simple_actions"`
+}
+
+// /v2/history/get_deltas request parameters
+//
+// Zero values are not sent.
+type DeltasRequest struct {
+	Code        Name
+	Scope       string
+	Table       Name
+	Payer       Name
+	Skip        uint32
+	Limit       uint32
+	Sort        SortOrder
+	After       time.Time
+	Before      time.Time
+	AfterBlock  uint32
+	BeforeBlock uint32
+	// Only rows that are present (true) or removed (false)
+	Present *bool
+}
+
+func (r DeltasRequest) values() url.Values {
+	v := url.Values{}
+
+	setNonEmpty(v, map[string]string{
+		"code":  string(r.Code),
+		"scope": r.Scope,
+		"table": string(r.Table),
+		"payer": string(r.Payer),
+		"sort":  string(r.Sort),
+	})
+
+	setSkipLimit(v, r.Skip, r.Limit)
+
+	if r.AfterBlock > 0 {
+		v.Set("after", strconv.FormatUint(uint64(r.AfterBlock), 10))
+	} else if !r.After.IsZero() {
+		v.Set("after", r.After.UTC().Format(hyperionTimeFormat))
+	}
+
+	if r.BeforeBlock > 0 {
+		v.Set("before", strconv.FormatUint(uint64(r.BeforeBlock), 10))
+	} else if !r.Before.IsZero() {
+		v.Set("before", r.Before.UTC().Format(hyperionTimeFormat))
+	}
+
+	if r.Present != nil {
+		v.Set("present", strconv.FormatBool(*r.Present))
+	}
+	return v
+}
+
+// Hyperion table delta format
+type HyperionDelta struct {
+	Timestamp  time.Time   `json:"timestamp" time_format:"2006-01-02T15:04:05.000"`
+	Present    bool        `json:"present"`
+	Code       Name        `json:"code"`
+	Scope      string      `json:"scope"`
+	Table      Name        `json:"table"`
+	PrimaryKey string      `json:"primary_key"`
+	Payer      Name        `json:"payer"`
+	BlockNum   uint32      `json:"block_num"`
+	BlockID    string      `json:"block_id"`
+	Data       interface{} `json:"data"`
+}
+
+func (d *HyperionDelta) UnmarshalJSON(b []byte) error {
+	type delta HyperionDelta
+	var r struct {
+		delta
+		// Older hyperion versions encode present as 0/1.
+		Present interface{} `json:"present"`
+	}
+
+	if err := json.Unmarshal(b, &r); err != nil {
+		return err
+	}
+
+	*d = HyperionDelta(r.delta)
+	switch p := r.Present.(type) {
+	case bool:
+		d.Present = p
+	case float64:
+		d.Present = p != 0
+	}
+	return nil
+}
+
+// /v2/history/get_deltas format
+type HyperionDeltas struct {
+	QueryTime float32         `json:"query_time_ms"`
+	Total     HyperionTotal   `json:"total"`
+	Deltas    []HyperionDelta `json:"deltas"`
+}
+
+// /v2/history/get_transaction format
+type HyperionTransaction struct {
+	QueryTime float32          `json:"query_time_ms"`
+	Executed  bool             `json:"executed"`
+	TrxID     string           `json:"trx_id"`
+	LIB       uint32           `json:"lib"`
+	CachedLib bool             `json:"cached_lib"`
+	Actions   []HyperionAction `json:"actions"`
+}
+
+// /v2/history/get_created_accounts request parameters
+type CreatedAccountsRequest struct {
+	Account Name
+	Skip    uint32
+	Limit   uint32
+}
+
+func (r CreatedAccountsRequest) values() url.Values {
+	v := url.Values{}
+	v.Set("account", string(r.Account))
+
+	setSkipLimit(v, r.Skip, r.Limit)
+	return v
+}
+
+// Account created by another account.
+type CreatedAccount struct {
+	Name      Name      `json:"name"`
+	Timestamp time.Time `json:"timestamp" time_format:"2006-01-02T15:04:05.000"`
+	TrxID     string    `json:"trx_id"`
+}
+
+// /v2/history/get_created_accounts format
+type CreatedAccounts struct {
+	QueryTime float32          `json:"query_time_ms"`
+	Total     HyperionTotal    `json:"total"`
+	Accounts  []CreatedAccount `json:"accounts"`
+}
+
+// /v2/history/get_creator format
+type AccountCreator struct {
+	QueryTime float32   `json:"query_time_ms"`
+	Account   Name      `json:"account"`
+	Creator   Name      `json:"creator"`
+	Timestamp time.Time `json:"timestamp" time_format:"2006-01-02T15:04:05.000"`
+	BlockNum  uint32    `json:"block_num"`
+	TrxID     string    `json:"trx_id"`
+}
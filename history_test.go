@@ -0,0 +1,348 @@
+package leapapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testHyperionAction = `{
+    "@timestamp": "2023-05-10T12:00:00.500",
+    "timestamp": "2023-05-10T12:00:00.500",
+    "block_num": 300000001,
+    "block_id": "11e1a301a1b2c3d4e5f60718293a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a",
+    "trx_id": "668b2b96ce802ca9599cc56042e5b5f4bb93763c30bc0693880f2fb85b573ebb",
+    "act": {
+        "account": "eosio.token",
+        "name": "transfer",
+        "authorization": [{"actor": "alice", "permission": "active"}],
+        "data": {"from": "alice", "to": "bob", "amount": 1, "symbol": "EOS", "quantity": "1.0000 EOS", "memo": "hi"}
+    },
+    "receipts": [{
+        "receiver": "eosio.token",
+        "global_sequence": "1000001",
+        "recv_sequence": 42,
+        "auth_sequence": [{"account": "alice", "sequence": 7}]
+    }],
+    "cpu_usage_us": 150,
+    "net_usage_words": 16,
+    "account_ram_deltas": [{"account": "bob", "delta": 240}],
+    "global_sequence": 1000001,
+    "producer": "eosio",
+    "action_ordinal": 1,
+    "creator_action_ordinal": 0,
+    "signatures": []
+}`
+
+func TestActionsRequest_Values(t *testing.T) {
+	req := ActionsRequest{
+		Account:     "alice",
+		Filter:      []ActionFilter{{"eosio.token", "transfer"}, {"eosio", "*"}},
+		Track:       true,
+		Skip:        10,
+		Limit:       100,
+		Sort:        SortAsc,
+		After:       time.Date(2023, 5, 10, 12, 0, 0, 0, time.UTC),
+		BeforeBlock: 1000,
+		Simple:      true,
+		NoBinary:    true,
+		CheckLib:    true,
+		Terms:       map[string]string{"act.authorization.actor": "alice"},
+	}.WithData("to", "bob").WithData("memo", "hi")
+
+	assert.Equal(t, "account=alice"+
+		"&act.authorization.actor=alice"+
+		"&act.data.memo=hi"+
+		"&act.data.to=bob"+
+		"&after=2023-05-10T12%3A00%3A00.000Z"+
+		"&before=1000"+
+		"&checkLib=true"+
+		"&filter=eosio.token%3Atransfer%2Ceosio%3A%2A"+
+		"&limit=100"+
+		"&noBinary=true"+
+		"&simple=true"+
+		"&skip=10"+
+		"&sort=asc"+
+		"&track=true", req.values().Encode())
+
+	assert.Equal(t, "", ActionsRequest{}.values().Encode())
+	assert.Equal(t, "after=5", ActionsRequest{After: time.Now(), AfterBlock: 5}.values().Encode())
+}
+
+func TestGetActions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v2/history/get_actions", req.URL.Path)
+		assert.Equal(t, "bob", req.URL.Query().Get("act.data.to"))
+		assert.Equal(t, "eosio.token:transfer", req.URL.Query().Get("filter"))
+
+		payload := `{
+            "query_time_ms": 12.5,
+            "cached": false,
+            "lib": 300000000,
+            "last_indexed_block": 300000010,
+            "last_indexed_block_time": "2023-05-10T12:00:05.000",
+            "total": {"value": 1, "relation": "eq"},
+            "actions": [` + testHyperionAction + `]
+        }`
+		_, _ = res.Write([]byte(payload))
+	}))
+
+	client := New(srv.URL)
+	actions, err := client.GetActions(context.Background(), ActionsRequest{
+		Filter:   []ActionFilter{{"eosio.token", "transfer"}},
+		CheckLib: true,
+	}.WithData("to", "bob"))
+	require.NoError(t, err)
+
+	assert.Equal(t, float32(12.5), actions.QueryTime)
+	assert.Equal(t, uint32(300000000), actions.LIB)
+	assert.Equal(t, uint32(300000010), actions.LastIndexedBlock)
+	assert.Equal(t, time.Date(2023, 5, 10, 12, 0, 5, 0, time.UTC), actions.LastIndexedBlockTime)
+	assert.Equal(t, HyperionTotal{Value: 1, Relation: "eq"}, actions.Total)
+	require.Len(t, actions.Actions, 1)
+
+	action := actions.Actions[0]
+	assert.Equal(t, time.Date(2023, 5, 10, 12, 0, 0, 500000000, time.UTC), action.Timestamp)
+	assert.Equal(t, uint32(300000001), action.BlockNum)
+	assert.Equal(t, testTrxID, action.TrxID)
+	assert.Equal(t, Name("eosio.token"), action.Act.Account)
+	assert.Equal(t, Name("transfer"), action.Act.Name)
+	assert.Equal(t, []PermissionLevel{{Actor: "alice", Permission: "active"}}, action.Act.Authorization)
+	assert.Equal(t, "bob", action.Act.Data.(map[string]interface{})["to"])
+	assert.Equal(t, []HyperionReceipt{{
+		Receiver:       "eosio.token",
+		GlobalSequence: 1000001,
+		RecvSequence:   42,
+		AuthSequence:   []HyperionAuthSequence{{Account: "alice", Sequence: 7}},
+	}}, action.Receipts)
+	assert.Equal(t, uint32(150), action.CPUUsageUS)
+	assert.Equal(t, uint32(16), action.NetUsageWords)
+	assert.Equal(t, []AccountDelta{{Account: "bob", Delta: 240}}, action.AccountRAMDeltas)
+	assert.Equal(t, Uint64(1000001), action.GlobalSequence)
+	assert.Equal(t, Name("eosio"), action.Producer)
+	assert.Equal(t, uint32(1), action.ActionOrdinal)
+	assert.Empty(t, action.Signatures)
+}
+
+func TestGetActionsSimple(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "true", req.URL.Query().Get("simple"))
+
+		payload := `{
+            "query_time_ms": 3.1,
+            "simple_actions": [{
+                "block": 300000001,
+                "irreversible": true,
+                "timestamp": "2023-05-10T12:00:00.500",
+                "transaction_id": "668b2b96ce802ca9599cc56042e5b5f4bb93763c30bc0693880f2fb85b573ebb",
+                "actors": "alice@active",
+                "notified": "eosio.token,alice,bob",
+                "contract": "eosio.token",
+                "action": "transfer",
+                "data": {"from": "alice", "to": "bob"}
+            }]
+        }`
+		_, _ = res.Write([]byte(payload))
+	}))
+
+	client := New(srv.URL)
+	actions, err := client.GetActions(context.Background(), ActionsRequest{Account: "alice", Simple: true})
+	require.NoError(t, err)
+
+	require.Len(t, actions.SimpleActions, 1)
+	action := actions.SimpleActions[0]
+	assert.Equal(t, uint32(300000001), action.Block)
+	assert.True(t, action.Irreversible)
+	assert.Equal(t, time.Date(2023, 5, 10, 12, 0, 0, 500000000, time.UTC), action.Timestamp)
+	assert.Equal(t, "alice@active", action.Actors)
+	assert.Equal(t, "eosio.token,alice,bob", action.Notified)
+	assert.Equal(t, Name("eosio.token"), action.Contract)
+	assert.Equal(t, Name("transfer"), action.Action)
+	assert.Empty(t, actions.Actions)
+}
+
+func TestDeltasRequest_Values(t *testing.T) {
+	present := false
+	req := DeltasRequest{
+		Code:       "eosio.token",
+		Scope:      "alice",
+		Table:      "accounts",
+		Payer:      "alice",
+		Limit:      10,
+		Sort:       SortDesc,
+		AfterBlock: 100,
+		Before:     time.Date(2023, 5, 10, 12, 0, 0, 0, time.UTC),
+		Present:    &present,
+	}
+
+	assert.Equal(t, "after=100"+
+		"&before=2023-05-10T12%3A00%3A00.000Z"+
+		"&code=eosio.token"+
+		"&limit=10"+
+		"&payer=alice"+
+		"&present=false"+
+		"&scope=alice"+
+		"&sort=desc"+
+		"&table=accounts", req.values().Encode())
+
+	assert.Equal(t, "", DeltasRequest{}.values().Encode())
+}
+
+func TestGetDeltas(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v2/history/get_deltas?code=eosio.token&table=accounts", req.URL.String())
+
+		payload := `{
+            "query_time_ms": 4.2,
+            "total": {"value": 2, "relation": "eq"},
+            "deltas": [
+                {
+                    "timestamp": "2023-05-10T12:00:00.500",
+                    "present": 1,
+                    "code": "eosio.token",
+                    "scope": "alice",
+                    "table": "accounts",
+                    "primary_key": "5459781",
+                    "payer": "alice",
+                    "block_num": 300000001,
+                    "block_id": "11e1a301a1b2c3d4e5f60718293a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a",
+                    "data": {"balance": "1.0000 EOS"}
+                },
+                {
+                    "timestamp": "2023-05-10T12:00:01.000",
+                    "present": false,
+                    "code": "eosio.token",
+                    "scope": "bob",
+                    "table": "accounts",
+                    "primary_key": "5459781",
+                    "payer": "bob",
+                    "block_num": 300000003
+                }
+            ]
+        }`
+		_, _ = res.Write([]byte(payload))
+	}))
+
+	client := New(srv.URL)
+	deltas, err := client.GetDeltas(context.Background(), DeltasRequest{Code: "eosio.token", Table: "accounts"})
+	require.NoError(t, err)
+
+	assert.Equal(t, float32(4.2), deltas.QueryTime)
+	assert.Equal(t, HyperionTotal{Value: 2, Relation: "eq"}, deltas.Total)
+
+	expected := []HyperionDelta{
+		{
+			Timestamp:  time.Date(2023, 5, 10, 12, 0, 0, 500000000, time.UTC),
+			Present:    true,
+			Code:       "eosio.token",
+			Scope:      "alice",
+			Table:      "accounts",
+			PrimaryKey: "5459781",
+			Payer:      "alice",
+			BlockNum:   300000001,
+			BlockID:    "11e1a301a1b2c3d4e5f60718293a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a",
+			Data:       map[string]interface{}{"balance": "1.0000 EOS"},
+		},
+		{
+			Timestamp:  time.Date(2023, 5, 10, 12, 0, 1, 0, time.UTC),
+			Present:    false,
+			Code:       "eosio.token",
+			Scope:      "bob",
+			Table:      "accounts",
+			PrimaryKey: "5459781",
+			Payer:      "bob",
+			BlockNum:   300000003,
+		},
+	}
+	assert.Equal(t, expected, deltas.Deltas)
+}
+
+func TestGetTransaction(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v2/history/get_transaction?id="+testTrxID, req.URL.String())
+
+		payload := `{
+            "query_time_ms": 1.5,
+            "executed": true,
+            "trx_id": "668b2b96ce802ca9599cc56042e5b5f4bb93763c30bc0693880f2fb85b573ebb",
+            "lib": 300000000,
+            "cached_lib": true,
+            "actions": [` + testHyperionAction + `]
+        }`
+		_, _ = res.Write([]byte(payload))
+	}))
+
+	client := New(srv.URL)
+	trx, err := client.GetTransaction(context.Background(), testTrxID)
+	require.NoError(t, err)
+
+	assert.Equal(t, float32(1.5), trx.QueryTime)
+	assert.True(t, trx.Executed)
+	assert.Equal(t, testTrxID, trx.TrxID)
+	assert.Equal(t, uint32(300000000), trx.LIB)
+	assert.True(t, trx.CachedLib)
+	require.Len(t, trx.Actions, 1)
+	assert.Equal(t, Name("transfer"), trx.Actions[0].Act.Name)
+}
+
+func TestGetCreatedAccounts(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v2/history/get_created_accounts?account=eosio&limit=2&skip=4", req.URL.String())
+
+		payload := `{
+            "query_time_ms": 2,
+            "total": {"value": 100, "relation": "gte"},
+            "accounts": [
+                {"name": "alice", "timestamp": "2018-06-08T08:08:08.500", "trx_id": "668b2b96ce802ca9599cc56042e5b5f4bb93763c30bc0693880f2fb85b573ebb"}
+            ]
+        }`
+		_, _ = res.Write([]byte(payload))
+	}))
+
+	client := New(srv.URL)
+	accounts, err := client.GetCreatedAccounts(context.Background(), CreatedAccountsRequest{Account: "eosio", Skip: 4, Limit: 2})
+	require.NoError(t, err)
+
+	assert.Equal(t, float32(2), accounts.QueryTime)
+	assert.Equal(t, HyperionTotal{Value: 100, Relation: "gte"}, accounts.Total)
+	assert.Equal(t, []CreatedAccount{{
+		Name:      "alice",
+		Timestamp: time.Date(2018, 6, 8, 8, 8, 8, 500000000, time.UTC),
+		TrxID:     testTrxID,
+	}}, accounts.Accounts)
+}
+
+func TestGetCreator(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v2/history/get_creator?account=alice", req.URL.String())
+
+		payload := `{
+            "query_time_ms": 0.8,
+            "account": "alice",
+            "creator": "eosio",
+            "timestamp": "2018-06-08T08:08:08.500",
+            "block_num": 1000,
+            "trx_id": "668b2b96ce802ca9599cc56042e5b5f4bb93763c30bc0693880f2fb85b573ebb"
+        }`
+		_, _ = res.Write([]byte(payload))
+	}))
+
+	client := New(srv.URL)
+	creator, err := client.GetCreator(context.Background(), "alice")
+	require.NoError(t, err)
+
+	expected := AccountCreator{
+		QueryTime: 0.8,
+		Account:   "alice",
+		Creator:   "eosio",
+		Timestamp: time.Date(2018, 6, 8, 8, 8, 8, 500000000, time.UTC),
+		BlockNum:  1000,
+		TrxID:     testTrxID,
+	}
+	assert.Equal(t, expected, creator)
+}
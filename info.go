@@ -11,7 +11,7 @@ type Info struct {
 	HeadBlockID               string    `json:"head_block_id"`
 	HeadBlockNum              int64     `json:"head_block_num"`
 	HeadBlockTime             time.Time `json:"head_block_time"`
-	HeadBlockProducer         string    `json:"head_block_producer"`
+	HeadBlockProducer         Name      `json:"head_block_producer"`
 	LastIrreversableBlockNum  int64     `json:"last_irreversible_block_num"`
 	LastIrreversableBlockID   string    `json:"last_irreversible_block_id"`
 	LastIrreversableBlockTime time.Time `json:"last_irreversible_block_time,omitempty"`
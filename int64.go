@@ -0,0 +1,33 @@
+package leapapi
+
+import (
+	"strconv"
+)
+
+// Int64 is a signed 64 bit integer that is decoded from either a
+// json number or a string (nodeos encodes large integers as strings).
+type Int64 int64
+
+func (v Int64) MarshalJSON() ([]byte, error) {
+	return json.Marshal(int64(v))
+}
+
+func (v *Int64) UnmarshalJSON(b []byte) error {
+	var n int64
+	if len(b) > 0 && b[0] == '"' {
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+
+		var err error
+		if n, err = strconv.ParseInt(s, 10, 64); err != nil {
+			return err
+		}
+	} else if err := json.Unmarshal(b, &n); err != nil {
+		return err
+	}
+
+	*v = Int64(n)
+	return nil
+}
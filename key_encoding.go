@@ -0,0 +1,132 @@
+package leapapi
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/ripemd160"
+)
+
+// KeyType is the curve/type of a key or signature
+// (index in the fc::crypto public_key/signature variants).
+type KeyType int
+
+const (
+	// secp256k1
+	KeyTypeK1 KeyType = iota
+	// secp256r1 (P-256)
+	KeyTypeR1
+	// WebAuthn
+	KeyTypeWA
+)
+
+var keyTypeNames = []string{"K1", "R1", "WA"}
+
+func (t KeyType) String() string {
+	if t < 0 || int(t) >= len(keyTypeNames) {
+		return fmt.Sprintf("KeyType(%d)", int(t))
+	}
+	return keyTypeNames[t]
+}
+
+// Legacy public key prefix (only used for K1 keys)
+const legacyPublicKeyPrefix = "EOS"
+
+func keyChecksum(data []byte, suffix string) []byte {
+	h := ripemd160.New()
+	_, _ = h.Write(data)
+	_, _ = h.Write([]byte(suffix))
+	return h.Sum(nil)[:4]
+}
+
+// Decode base58 data with a ripemd160 checksum appended.
+func decodeKeyData(s string, suffix string) ([]byte, error) {
+	data, err := base58Decode(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < 5 {
+		return nil, fmt.Errorf("key data too short")
+	}
+
+	data, sum := data[:len(data)-4], data[len(data)-4:]
+	if !bytes.Equal(sum, keyChecksum(data, suffix)) {
+		return nil, fmt.Errorf("checksum mismatch")
+	}
+	return data, nil
+}
+
+func encodeKeyData(data []byte, suffix string) string {
+	return base58Encode(append(append([]byte{}, data...), keyChecksum(data, suffix)...))
+}
+
+// Decode a "<prefix>_<type>_<data>" string.
+func decodeTypedKeyString(s string, prefix string) (KeyType, []byte, error) {
+	if !strings.HasPrefix(s, prefix+"_") {
+		return 0, nil, fmt.Errorf("invalid prefix")
+	}
+
+	parts := strings.SplitN(s[len(prefix)+1:], "_", 2)
+	if len(parts) != 2 {
+		return 0, nil, fmt.Errorf("invalid format")
+	}
+
+	for t, name := range keyTypeNames {
+		if name == parts[0] {
+			data, err := decodeKeyData(parts[1], name)
+			return KeyType(t), data, err
+		}
+	}
+	return 0, nil, fmt.Errorf("unknown key type %q", parts[0])
+}
+
+// Decode a public key string (legacy "EOS..." or "PUB_<type>_...")
+// into key type and raw key data.
+func decodePublicKeyString(s string) (KeyType, []byte, error) {
+	var t KeyType
+	var data []byte
+	var err error
+
+	if strings.HasPrefix(s, legacyPublicKeyPrefix) {
+		t = KeyTypeK1
+		data, err = decodeKeyData(s[len(legacyPublicKeyPrefix):], "")
+	} else {
+		t, data, err = decodeTypedKeyString(s, "PUB")
+	}
+
+	if err == nil && t != KeyTypeWA && len(data) != 33 {
+		err = fmt.Errorf("invalid key length %d", len(data))
+	}
+
+	if err != nil {
+		return 0, nil, fmt.Errorf("public key: %v", err)
+	}
+	return t, data, nil
+}
+
+// Encode a public key. K1 keys use the legacy "EOS" format.
+func encodePublicKeyString(t KeyType, data []byte) string {
+	if t == KeyTypeK1 {
+		return legacyPublicKeyPrefix + encodeKeyData(data, "")
+	}
+	return "PUB_" + t.String() + "_" + encodeKeyData(data, t.String())
+}
+
+// Decode a "SIG_<type>_..." string into signature type and raw data.
+func decodeSignatureString(s string) (KeyType, []byte, error) {
+	t, data, err := decodeTypedKeyString(s, "SIG")
+	if err == nil && t != KeyTypeWA && len(data) != 65 {
+		err = fmt.Errorf("invalid signature length %d", len(data))
+	}
+
+	if err != nil {
+		return 0, nil, fmt.Errorf("signature: %v", err)
+	}
+	return t, data, nil
+}
+
+func encodeSignatureString(t KeyType, data []byte) string {
+	return "SIG_" + t.String() + "_" + encodeKeyData(data, t.String())
+}
@@ -0,0 +1,135 @@
+package leapapi
+
+import (
+	"fmt"
+	"strings"
+)
+
+const nameCharmap = ".12345abcdefghijklmnopqrstuvwxyz"
+
+func nameCharToSymbol(c byte) uint64 {
+	switch {
+	case c >= 'a' && c <= 'z':
+		return uint64(c-'a') + 6
+	case c >= '1' && c <= '5':
+		return uint64(c-'1') + 1
+	}
+	return 0
+}
+
+// Convert a name string to its uint64 representation.
+func nameToUint64(s string) (uint64, error) {
+	if len(s) > 13 {
+		return 0, fmt.Errorf("name: %q is too long", s)
+	}
+
+	var v uint64
+	for i := 0; i < len(s); i++ {
+		c := nameCharToSymbol(s[i])
+		if i < 12 {
+			v |= (c & 0x1f) << (64 - 5*(i+1))
+		} else {
+			v |= c & 0x0f
+		}
+	}
+
+	// Name must be normalized (valid charset, no trailing dots
+	// and 13th character in range).
+	if uint64ToName(v) != s {
+		return 0, fmt.Errorf("name: %q is not a valid name", s)
+	}
+	return v, nil
+}
+
+// Convert uint64 representation of a name to a string.
+func uint64ToName(v uint64) string {
+	str := []byte(strings.Repeat(".", 13))
+
+	for i := 0; i <= 12; i++ {
+		if i == 0 {
+			str[12-i] = nameCharmap[v&0x0f]
+			v >>= 4
+		} else {
+			str[12-i] = nameCharmap[v&0x1f]
+			v >>= 5
+		}
+	}
+
+	return strings.TrimRight(string(str), ".")
+}
+
+// Name is an antelope name (account, action, table, permission etc).
+//
+// Names are up to 13 characters long. The first 12 characters
+// can be any of `.12345abcdefghijklmnopqrstuvwxyz` and the 13th
+// character can only be one of `.12345abcdefghij`.
+// Names can not end with a dot.
+type Name string
+
+// ParseName parses and validates a name.
+func ParseName(s string) (Name, error) {
+	if _, err := nameToUint64(s); err != nil {
+		return "", err
+	}
+	return Name(s), nil
+}
+
+// NameFromUint64 returns the name represented by v.
+func NameFromUint64(v uint64) Name {
+	return Name(uint64ToName(v))
+}
+
+// Uint64 returns the uint64 representation of the name.
+func (n Name) Uint64() (uint64, error) {
+	return nameToUint64(string(n))
+}
+
+// IsValid returns true if the name is valid.
+func (n Name) IsValid() bool {
+	_, err := n.Uint64()
+	return err == nil
+}
+
+func (n Name) String() string {
+	return string(n)
+}
+
+// Compare returns -1, 0 or 1 if n is less than, equal to or greater than o.
+// Names are compared by their uint64 value (same order as on chain).
+func (n Name) Compare(o Name) int {
+	a, _ := n.Uint64()
+	b, _ := o.Uint64()
+
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// Less returns true if n is less than o.
+func (n Name) Less(o Name) bool {
+	return n.Compare(o) < 0
+}
+
+func (n Name) MarshalJSON() ([]byte, error) {
+	if !n.IsValid() {
+		return nil, fmt.Errorf("name: %q is not a valid name", string(n))
+	}
+	return json.Marshal(string(n))
+}
+
+func (n *Name) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	name, err := ParseName(s)
+	if err == nil {
+		*n = name
+	}
+	return err
+}
@@ -0,0 +1,92 @@
+package leapapi
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestName_Uint64(t *testing.T) {
+	tests := []struct {
+		name  string
+		value uint64
+	}{
+		{"", 0},
+		{"eosio", 0x5530ea0000000000},
+		{"eosio.token", 0x5530ea033482a600},
+		{"zzzzzzzzzzzzj", 0xffffffffffffffff},
+	}
+
+	for _, test := range tests {
+		v, err := nameToUint64(test.name)
+		require.NoError(t, err)
+		assert.Equal(t, test.value, v)
+		assert.Equal(t, test.name, uint64ToName(test.value))
+	}
+
+	for _, invalid := range []string{"EOSIO", "eosio.", "toolongname12345", "zzzzzzzzzzzzz"} {
+		_, err := nameToUint64(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestParseName(t *testing.T) {
+	n, err := ParseName("eosio.token")
+	require.NoError(t, err)
+	assert.Equal(t, Name("eosio.token"), n)
+
+	_, err = ParseName("eosio.toke6")
+	assert.EqualError(t, err, `name: "eosio.toke6" is not a valid name`)
+
+	_, err = ParseName("aaaaaaaaaaaaaa")
+	assert.EqualError(t, err, `name: "aaaaaaaaaaaaaa" is too long`)
+}
+
+func TestName_Uint64Conversion(t *testing.T) {
+	v, err := Name("eosio").Uint64()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(6138663577826885632), v)
+	assert.Equal(t, Name("eosio"), NameFromUint64(v))
+
+	_, err = Name("Eosio").Uint64()
+	assert.Error(t, err)
+
+	assert.True(t, Name("eosio").IsValid())
+	assert.True(t, Name("").IsValid())
+	assert.False(t, Name("eosio..").IsValid())
+}
+
+func TestName_Compare(t *testing.T) {
+	assert.Equal(t, 0, Name("eosio").Compare("eosio"))
+	assert.Equal(t, -1, Name("a").Compare("b"))
+	assert.Equal(t, 1, Name("eosio.token").Compare("eosio"))
+	assert.True(t, Name("eosio").Less("eosio.token"))
+	assert.False(t, Name("zzz").Less("aaa"))
+
+	names := []Name{"zed", "alice", "a.b", "a", "bob", "1"}
+	sort.Slice(names, func(i, j int) bool { return names[i].Less(names[j]) })
+	assert.Equal(t, []Name{"1", "a", "a.b", "alice", "bob", "zed"}, names)
+}
+
+func TestName_JSON(t *testing.T) {
+	var v struct {
+		Account Name `json:"account"`
+	}
+
+	require.NoError(t, json.Unmarshal([]byte(`{"account":"eosio.token"}`), &v))
+	assert.Equal(t, Name("eosio.token"), v.Account)
+
+	out, err := json.Marshal(v)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"account":"eosio.token"}`, string(out))
+
+	err = json.Unmarshal([]byte(`{"account":"EOSIO"}`), &v)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `name: "EOSIO" is not a valid name`)
+
+	_, err = json.Marshal(Name("EOSIO"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `name: "EOSIO" is not a valid name`)
+}
@@ -0,0 +1,180 @@
+package leapapi
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+// Version byte of WIF encoded private keys.
+const wifVersion = 0x80
+
+// PrivateKey is a secp256k1 (K1) private key.
+type PrivateKey struct {
+	key *secp256k1.PrivateKey
+}
+
+// ParsePrivateKey parses a private key in either WIF (legacy) or "PVT_K1_" format.
+func ParsePrivateKey(s string) (*PrivateKey, error) {
+	var data []byte
+	var err error
+
+	if strings.HasPrefix(s, "PVT_") {
+		var t KeyType
+		t, data, err = decodeTypedKeyString(s, "PVT")
+		if err == nil && t != KeyTypeK1 {
+			err = fmt.Errorf("unsupported key type %s", t)
+		}
+	} else {
+		data, err = decodeWIF(s)
+	}
+
+	if err == nil && len(data) != 32 {
+		err = fmt.Errorf("invalid key length %d", len(data))
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("private key: %v", err)
+	}
+	return &PrivateKey{key: secp256k1.PrivKeyFromBytes(data)}, nil
+}
+
+func sha256d(data []byte) []byte {
+	h := sha256.Sum256(data)
+	h = sha256.Sum256(h[:])
+	return h[:]
+}
+
+func decodeWIF(s string) ([]byte, error) {
+	data, err := base58Decode(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) != 37 || data[0] != wifVersion {
+		return nil, fmt.Errorf("invalid wif format")
+	}
+
+	data, sum := data[:33], data[33:]
+	if !bytes.Equal(sum, sha256d(data)[:4]) {
+		return nil, fmt.Errorf("checksum mismatch")
+	}
+	return data[1:], nil
+}
+
+// String returns the key in "PVT_K1_" format.
+func (k *PrivateKey) String() string {
+	return "PVT_K1_" + encodeKeyData(k.key.Serialize(), "K1")
+}
+
+// WIF returns the key in legacy WIF format.
+func (k *PrivateKey) WIF() string {
+	data := append([]byte{wifVersion}, k.key.Serialize()...)
+	return base58Encode(append(data, sha256d(data)[:4]...))
+}
+
+// PublicKey returns the public key.
+func (k *PrivateKey) PublicKey() PublicKey {
+	return PublicKey{Type: KeyTypeK1, Data: k.key.PubKey().SerializeCompressed()}
+}
+
+// Sign signs a sha256 digest.
+func (k *PrivateKey) Sign(digest []byte) (Signature, error) {
+	if len(digest) != sha256.Size {
+		return Signature{}, fmt.Errorf("sign: digest must be %d bytes", sha256.Size)
+	}
+	return Signature{Type: KeyTypeK1, Data: signCanonical(k.key, digest)}, nil
+}
+
+func (k *PrivateKey) MarshalJSON() ([]byte, error) {
+	return json.Marshal(k.String())
+}
+
+func (k *PrivateKey) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	key, err := ParsePrivateKey(s)
+	if err == nil {
+		*k = *key
+	}
+	return err
+}
+
+// Nodeos only accepts "canonical" signatures, where both r and s
+// are exactly 32 bytes when DER encoded.
+func isCanonical(sig []byte) bool {
+	return sig[1]&0x80 == 0 && !(sig[1] == 0 && sig[2]&0x80 == 0) &&
+		sig[33]&0x80 == 0 && !(sig[33] == 0 && sig[34]&0x80 == 0)
+}
+
+// Produce a compact (recoverable) canonical signature.
+//
+// Nonces are generated according to RFC6979. If the signature
+// is not canonical, a counter is passed as extra data to the nonce
+// function until a canonical signature is found.
+func signCanonical(key *secp256k1.PrivateKey, hash []byte) []byte {
+	privKey := key.Serialize()
+
+	var e secp256k1.ModNScalar
+	e.SetByteSlice(hash)
+
+	for attempt := uint32(0); ; attempt++ {
+		var extra []byte
+		if attempt > 0 {
+			extra = make([]byte, 32)
+			binary.BigEndian.PutUint32(extra[28:], attempt)
+		}
+
+		for iteration := uint32(0); ; iteration++ {
+			k := secp256k1.NonceRFC6979(privKey, hash, extra, nil, iteration)
+
+			var kG secp256k1.JacobianPoint
+			secp256k1.ScalarBaseMultNonConst(k, &kG)
+			kG.ToAffine()
+
+			// r = kG.x mod N
+			var r secp256k1.ModNScalar
+			xBytes := kG.X.Bytes()
+			overflow := r.SetByteSlice(xBytes[:])
+			if r.IsZero() {
+				continue
+			}
+
+			recovery := byte(kG.Y.IsOddBit())
+			if overflow {
+				recovery |= 2
+			}
+
+			// s = k^-1(e + dr) mod N
+			kInv := new(secp256k1.ModNScalar).InverseValNonConst(k)
+			s := new(secp256k1.ModNScalar).Mul2(&key.Key, &r).Add(&e).Mul(kInv)
+			if s.IsZero() {
+				continue
+			}
+
+			// Use low s (flips the y coordinate of kG).
+			if s.IsOverHalfOrder() {
+				s.Negate()
+				recovery ^= 1
+			}
+
+			// 27 + 4 (compressed key) + recovery id
+			sig := make([]byte, 65)
+			sig[0] = 27 + 4 + recovery
+			r.PutBytesUnchecked(sig[1:33])
+			s.PutBytesUnchecked(sig[33:65])
+
+			if isCanonical(sig) {
+				return sig
+			}
+			break
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package leapapi
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testWIF       = "5KQwrPbwdL6PhXujxW37FSSQZ1JiwsST4cqQzDeyXtP79zkvFD3"
+	testPVT       = "PVT_K1_2bfGi9rYsXQSXXTvJbDAPhHLQUojjaNLomdm3cEJ1XTzMqUt3V"
+	testPublicKey = "EOS6MRyAjQq8ud7hVNYcfnVPJqcVpscN5So8BhtHuGYqET5GDW5CV"
+)
+
+func TestParsePrivateKey(t *testing.T) {
+	for _, s := range []string{testWIF, testPVT} {
+		key, err := ParsePrivateKey(s)
+		require.NoError(t, err)
+		assert.Equal(t, testWIF, key.WIF())
+		assert.Equal(t, testPVT, key.String())
+		assert.Equal(t, testPublicKey, key.PublicKey().String())
+	}
+}
+
+func TestParsePrivateKeyErrors(t *testing.T) {
+	tests := []struct {
+		key string
+		err string
+	}{
+		{"5KQwrPbwdL6PhXujxW37FSSQZ1JiwsST4cqQzDeyXtP79zkvFD4", "private key: checksum mismatch"},
+		{"PVT_K1_2bfGi9rYsXQSXXTvJbDAPhHLQUojjaNLomdm3cEJ1XTzMqUt3W", "private key: checksum mismatch"},
+		{"PVT_R1_2bfGi9rYsXQSXXTvJbDAPhHLQUojjaNLomdm3cEJ1XTzMqUt3V", "private key: checksum mismatch"},
+		{"abc", "private key: invalid wif format"},
+		{"0OIl", "private key: invalid base58 string"},
+	}
+
+	for _, test := range tests {
+		_, err := ParsePrivateKey(test.key)
+		assert.EqualError(t, err, test.err, test.key)
+	}
+}
+
+func TestPrivateKey_JSON(t *testing.T) {
+	var key PrivateKey
+	require.NoError(t, json.Unmarshal([]byte(`"`+testWIF+`"`), &key))
+	assert.Equal(t, testPVT, key.String())
+
+	data, err := json.Marshal(&key)
+	require.NoError(t, err)
+	assert.Equal(t, `"`+testPVT+`"`, string(data))
+
+	assert.Error(t, json.Unmarshal([]byte(`"abc"`), &key))
+}
+
+func TestPrivateKey_Sign(t *testing.T) {
+	key, err := ParsePrivateKey(testWIF)
+	require.NoError(t, err)
+
+	for _, msg := range []string{"", "hello", "leapapi", "some other message"} {
+		digest := sha256.Sum256([]byte(msg))
+
+		sig, err := key.Sign(digest[:])
+		require.NoError(t, err)
+		assert.Regexp(t, "^SIG_K1_", sig.String())
+
+		// Deterministic
+		sig2, err := key.Sign(digest[:])
+		require.NoError(t, err)
+		assert.Equal(t, sig, sig2)
+
+		data := sig.Data
+		assert.Equal(t, KeyTypeK1, sig.Type)
+		assert.True(t, isCanonical(data))
+
+		pub, compressed, err := ecdsa.RecoverCompact(data, digest[:])
+		require.NoError(t, err)
+		assert.True(t, compressed)
+		assert.Equal(t, testPublicKey, encodePublicKeyString(KeyTypeK1, pub.SerializeCompressed()))
+
+		// First attempt is plain RFC6979.
+		if compact := ecdsa.SignCompact(key.key, digest[:], true); isCanonical(compact) {
+			assert.Equal(t, compact, data)
+		}
+	}
+
+	_, err = key.Sign([]byte{1, 2, 3})
+	assert.EqualError(t, err, "sign: digest must be 32 bytes")
+}
@@ -0,0 +1,122 @@
+package leapapi
+
+import (
+	"context"
+	"strconv"
+	"time"
+)
+
+// Vote weight. The chain stores vote weights as doubles
+// and the api encodes them as decimal strings.
+type VoteWeight float64
+
+func (w VoteWeight) String() string {
+	return strconv.FormatFloat(float64(w), 'f', 17, 64)
+}
+
+func (w VoteWeight) MarshalJSON() ([]byte, error) {
+	return json.Marshal(w.String())
+}
+
+func (w *VoteWeight) UnmarshalJSON(b []byte) error {
+	var str string
+
+	err := json.Unmarshal(b, &str)
+	if err != nil {
+		return err
+	}
+
+	v, err := strconv.ParseFloat(str, 64)
+	if err == nil {
+		*w = VoteWeight(v)
+	}
+	return err
+}
+
+// Producer format (rows from get_producers)
+type Producer struct {
+	Owner             Name                   `json:"owner"`
+	TotalVotes        VoteWeight             `json:"total_votes"`
+	ProducerKey       PublicKey              `json:"producer_key"`
+	IsActive          uint8                  `json:"is_active"`
+	URL               string                 `json:"url"`
+	UnpaidBlocks      uint32                 `json:"unpaid_blocks"`
+	LastClaimTime     time.Time              `json:"last_claim_time" time_format:"2006-01-02T15:04:05.000"`
+	Location          uint16                 `json:"location"`
+	ProducerAuthority *BlockSigningAuthority `json:"producer_authority,omitempty"`
+}
+
+// get_producers request parameters
+type ProducersRequest struct {
+	LowerBound string `json:"lower_bound,omitempty"`
+	Limit      uint32 `json:"limit,omitempty"`
+}
+
+// get_producers format
+//
+// More is the owner to continue from or empty if there are no more rows.
+type Producers struct {
+	Rows                    []Producer `json:"rows"`
+	TotalProducerVoteWeight VoteWeight `json:"total_producer_vote_weight"`
+	More                    string     `json:"more"`
+}
+
+// get_producer_schedule format
+type ProducerSchedule struct {
+	Active   ProducerAuthoritySchedule  `json:"active"`
+	Pending  *ProducerAuthoritySchedule `json:"pending"`
+	Proposed *ProducerAuthoritySchedule `json:"proposed"`
+}
+
+// ProducersIterator iterates over all producers,
+// following "more" to fetch the next page when needed.
+type ProducersIterator struct {
+	client *Client
+	req    ProducersRequest
+	rows   []Producer
+	row    Producer
+	more   bool
+	err    error
+}
+
+// NewProducersIterator creates a new iterator starting at req.
+func (c *Client) NewProducersIterator(req ProducersRequest) *ProducersIterator {
+	return &ProducersIterator{
+		client: c,
+		req:    req,
+		more:   true,
+	}
+}
+
+// Next advances the iterator to the next producer.
+// Returns false when there are no more producers or an error occurred.
+func (it *ProducersIterator) Next(ctx context.Context) bool {
+	for len(it.rows) < 1 {
+		if !it.more || it.err != nil {
+			return false
+		}
+
+		res, err := it.client.GetProducers(ctx, it.req)
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.more = len(res.More) > 0
+		it.req.LowerBound = res.More
+		it.rows = res.Rows
+	}
+
+	it.row, it.rows = it.rows[0], it.rows[1:]
+	return true
+}
+
+// Producer returns the current producer.
+func (it *ProducersIterator) Producer() Producer {
+	return it.row
+}
+
+// Err returns the error (if any) that stopped the iteration.
+func (it *ProducersIterator) Err() error {
+	return it.err
+}
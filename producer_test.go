@@ -0,0 +1,132 @@
+package leapapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProducers_JsonDecode(t *testing.T) {
+	payload := `{
+  "rows": [
+    {
+      "owner": "eosnationftw",
+      "total_votes": "3426389125434316288.00000000000000000",
+      "producer_key": "EOS7EarnUhcyYqmdnPon8rm7mBCTnBoot6o7fE2WzjvEX2TdggbL3",
+      "is_active": 1,
+      "url": "https://eosnation.io",
+      "unpaid_blocks": 1740,
+      "last_claim_time": "2023-01-16T09:11:50.500",
+      "location": 124,
+      "producer_authority": [0, {"threshold": 1, "keys": [{"key": "EOS7EarnUhcyYqmdnPon8rm7mBCTnBoot6o7fE2WzjvEX2TdggbL3", "weight": 1}]}]
+    },
+    {
+      "owner": "oldproducer1",
+      "total_votes": "0.00000000000000000",
+      "producer_key": "EOS1111111111111111111111111111111114T1Anm",
+      "is_active": 0,
+      "url": "",
+      "unpaid_blocks": 0,
+      "last_claim_time": "1970-01-01T00:00:00.000",
+      "location": 0
+    }
+  ],
+  "total_producer_vote_weight": "137251269063227506688.00000000000000000",
+  "more": "eostribeprod"
+}`
+
+	var producers Producers
+	err := json.Unmarshal([]byte(payload), &producers)
+	require.NoError(t, err)
+
+	assert.Equal(t, "eostribeprod", producers.More)
+	assert.Equal(t, VoteWeight(137251269063227506688), producers.TotalProducerVoteWeight)
+	require.Len(t, producers.Rows, 2)
+
+	p := producers.Rows[0]
+	assert.Equal(t, Name("eosnationftw"), p.Owner)
+	assert.Equal(t, VoteWeight(3426389125434316288), p.TotalVotes)
+	assert.Equal(t, uint8(1), p.IsActive)
+	assert.Equal(t, uint32(1740), p.UnpaidBlocks)
+	assert.Equal(t, time.Date(2023, 1, 16, 9, 11, 50, 500000000, time.UTC), p.LastClaimTime)
+	assert.Equal(t, uint16(124), p.Location)
+	require.NotNil(t, p.ProducerAuthority)
+	assert.Equal(t, uint32(1), p.ProducerAuthority.Threshold)
+
+	assert.Nil(t, producers.Rows[1].ProducerAuthority)
+	assert.True(t, producers.Rows[0].TotalVotes > producers.Rows[1].TotalVotes)
+}
+
+func TestVoteWeight_Json(t *testing.T) {
+	payload, err := json.Marshal(VoteWeight(1.5))
+	require.NoError(t, err)
+	assert.Equal(t, `"1.50000000000000000"`, string(payload))
+
+	var w VoteWeight
+	assert.Error(t, json.Unmarshal([]byte(`"abc"`), &w))
+}
+
+func TestProducersIterator(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v1/chain/get_producers", req.URL.String())
+
+		var params struct {
+			LowerBound string `json:"lower_bound"`
+			JSON       bool   `json:"json"`
+		}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&params))
+		assert.True(t, params.JSON)
+
+		if params.LowerBound == "" {
+			_, _ = res.Write([]byte(`{"rows": [{"owner": "a", "total_votes": "2.0"}, {"owner": "b", "total_votes": "1.0"}], "more": "c"}`))
+		} else {
+			assert.Equal(t, "c", params.LowerBound)
+			_, _ = res.Write([]byte(`{"rows": [{"owner": "c", "total_votes": "0.5"}], "more": ""}`))
+		}
+	}))
+
+	client := New(srv.URL)
+	it := client.NewProducersIterator(ProducersRequest{Limit: 2})
+
+	owners := []Name{}
+	for it.Next(context.Background()) {
+		owners = append(owners, it.Producer().Owner)
+	}
+
+	require.NoError(t, it.Err())
+	assert.Equal(t, []Name{"a", "b", "c"}, owners)
+}
+
+func TestGetProducerSchedule(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v1/chain/get_producer_schedule", req.URL.String())
+
+		payload := `{
+            "active": {
+                "version": 2167,
+                "producers": [
+                    {"producer_name": "aus1genereos", "authority": [0, {"threshold": 1, "keys": [{"key": "EOS6zSgKm6pFyHVPX5a1t5GEU4aQkFR6DKFoTP7cHmbmWGs1EfKBH", "weight": 1}]}]}
+                ]
+            },
+            "pending": null,
+            "proposed": {"version": 2168, "producers": []}
+        }`
+		_, _ = res.Write([]byte(payload))
+	}))
+
+	client := New(srv.URL)
+	schedule, err := client.GetProducerSchedule(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2167), schedule.Active.Version)
+	require.Len(t, schedule.Active.Producers, 1)
+	assert.Equal(t, Name("aus1genereos"), schedule.Active.Producers[0].ProducerName)
+	assert.Nil(t, schedule.Pending)
+	require.NotNil(t, schedule.Proposed)
+	assert.Equal(t, uint32(2168), schedule.Proposed.Version)
+}
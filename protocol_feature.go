@@ -0,0 +1,163 @@
+package leapapi
+
+import "context"
+
+// get_activated_protocol_features request parameters
+//
+// Bounds are activation ordinals, or block numbers if SearchByBlockNum is set.
+// Nil bounds are not sent (0 is a valid bound).
+type ProtocolFeaturesRequest struct {
+	LowerBound       *uint32 `json:"lower_bound,omitempty"`
+	UpperBound       *uint32 `json:"upper_bound,omitempty"`
+	Limit            uint32  `json:"limit,omitempty"`
+	SearchByBlockNum bool    `json:"search_by_block_num,omitempty"`
+	Reverse          bool    `json:"reverse,omitempty"`
+}
+
+// Protocol feature specification entry
+type ProtocolFeatureSpec struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Activated protocol feature
+type ProtocolFeature struct {
+	FeatureDigest       string                `json:"feature_digest"`
+	ActivationOrdinal   uint32                `json:"activation_ordinal"`
+	ActivationBlockNum  uint32                `json:"activation_block_num"`
+	DescriptionDigest   string                `json:"description_digest"`
+	Dependencies        []string              `json:"dependencies"`
+	ProtocolFeatureType string                `json:"protocol_feature_type"`
+	Specification       []ProtocolFeatureSpec `json:"specification"`
+}
+
+// Codename returns the builtin feature codename (e.g. "GET_BLOCK_NUM")
+// or an empty string if the feature has none.
+func (f ProtocolFeature) Codename() string {
+	for _, spec := range f.Specification {
+		if spec.Name == "builtin_feature_codename" {
+			return spec.Value
+		}
+	}
+	return ""
+}
+
+// get_activated_protocol_features format
+//
+// More is the bound to continue from or nil if there are no more features.
+type ActivatedProtocolFeatures struct {
+	Features []ProtocolFeature `json:"activated_protocol_features"`
+	More     *uint32           `json:"more"`
+}
+
+// ProtocolFeaturesIterator iterates over all activated protocol features,
+// following "more" to fetch the next page when needed.
+type ProtocolFeaturesIterator struct {
+	client *Client
+	req    ProtocolFeaturesRequest
+	rows   []ProtocolFeature
+	row    ProtocolFeature
+	more   bool
+	err    error
+}
+
+// NewProtocolFeaturesIterator creates a new iterator starting at req.
+func (c *Client) NewProtocolFeaturesIterator(req ProtocolFeaturesRequest) *ProtocolFeaturesIterator {
+	return &ProtocolFeaturesIterator{
+		client: c,
+		req:    req,
+		more:   true,
+	}
+}
+
+// Next advances the iterator to the next feature.
+// Returns false when there are no more features or an error occurred.
+func (it *ProtocolFeaturesIterator) Next(ctx context.Context) bool {
+	for len(it.rows) < 1 {
+		if !it.more || it.err != nil {
+			return false
+		}
+
+		res, err := it.client.GetActivatedProtocolFeatures(ctx, it.req)
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.more = res.More != nil
+		if it.more {
+			bound := *res.More
+			if it.req.Reverse {
+				it.req.UpperBound = &bound
+			} else {
+				it.req.LowerBound = &bound
+			}
+		}
+		it.rows = res.Features
+	}
+
+	it.row, it.rows = it.rows[0], it.rows[1:]
+	return true
+}
+
+// Feature returns the current feature.
+func (it *ProtocolFeaturesIterator) Feature() ProtocolFeature {
+	return it.row
+}
+
+// Err returns the error (if any) that stopped the iteration.
+func (it *ProtocolFeaturesIterator) Err() error {
+	return it.err
+}
+
+// ProtocolFeatures is a set of activated protocol features
+// that can be looked up by codename or feature digest.
+type ProtocolFeatures struct {
+	byCodename map[string]ProtocolFeature
+	byDigest   map[string]ProtocolFeature
+}
+
+// NewProtocolFeatures creates a new set from features.
+func NewProtocolFeatures(features []ProtocolFeature) ProtocolFeatures {
+	set := ProtocolFeatures{
+		byCodename: make(map[string]ProtocolFeature),
+		byDigest:   make(map[string]ProtocolFeature),
+	}
+
+	for _, f := range features {
+		set.byDigest[f.FeatureDigest] = f
+		if name := f.Codename(); len(name) > 0 {
+			set.byCodename[name] = f
+		}
+	}
+	return set
+}
+
+// IsActivated returns true if the builtin feature codename is activated.
+func (p ProtocolFeatures) IsActivated(codename string) bool {
+	_, ok := p.byCodename[codename]
+	return ok
+}
+
+// IsDigestActivated returns true if the feature digest is activated.
+func (p ProtocolFeatures) IsDigestActivated(digest string) bool {
+	_, ok := p.byDigest[digest]
+	return ok
+}
+
+// Get returns the feature with the codename.
+func (p ProtocolFeatures) Get(codename string) (ProtocolFeature, bool) {
+	f, ok := p.byCodename[codename]
+	return f, ok
+}
+
+// GetByDigest returns the feature with the feature digest.
+func (p ProtocolFeatures) GetByDigest(digest string) (ProtocolFeature, bool) {
+	f, ok := p.byDigest[digest]
+	return f, ok
+}
+
+// Len returns the number of features in the set.
+func (p ProtocolFeatures) Len() int {
+	return len(p.byDigest)
+}
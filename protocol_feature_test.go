@@ -0,0 +1,143 @@
+package leapapi
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testPreactivateDigest  = "0ec7e080177b2c02b278d5088611686b49d739925a92d9bfcacd7fc6b74053bd"
+	testGetBlockNumDigest  = "35c2186cc36f7bb4aeaf4487b36e57039ccf45a9136aa856a5d569ecca55ef2b"
+	testBillFirstDigest    = "8ba52fe7a3956c5cd3a656a3174b931d3bb2abb45578befc59f283ecd816a405"
+	testProtocolFeatureFmt = `{
+        "feature_digest": "%s",
+        "activation_ordinal": %d,
+        "activation_block_num": %d,
+        "description_digest": "64fe7df32e9b86be2b296b3f81dfd527f84e82b98e363bc97e40bc7a83733310",
+        "dependencies": [],
+        "protocol_feature_type": "builtin",
+        "specification": [{"name": "builtin_feature_codename", "value": "%s"}]
+    }`
+)
+
+func testProtocolFeatureJSON(digest string, ordinal, block int, codename string) string {
+	return fmt.Sprintf(testProtocolFeatureFmt, digest, ordinal, block, codename)
+}
+
+func TestProtocolFeature_JsonDecode(t *testing.T) {
+	var feature ProtocolFeature
+	err := json.Unmarshal([]byte(testProtocolFeatureJSON(testPreactivateDigest, 0, 4, "PREACTIVATE_FEATURE")), &feature)
+	require.NoError(t, err)
+
+	expected := ProtocolFeature{
+		FeatureDigest:       testPreactivateDigest,
+		ActivationOrdinal:   0,
+		ActivationBlockNum:  4,
+		DescriptionDigest:   "64fe7df32e9b86be2b296b3f81dfd527f84e82b98e363bc97e40bc7a83733310",
+		Dependencies:        []string{},
+		ProtocolFeatureType: "builtin",
+		Specification:       []ProtocolFeatureSpec{{Name: "builtin_feature_codename", Value: "PREACTIVATE_FEATURE"}},
+	}
+	assert.Equal(t, expected, feature)
+	assert.Equal(t, "PREACTIVATE_FEATURE", feature.Codename())
+	assert.Equal(t, "", ProtocolFeature{}.Codename())
+}
+
+func TestGetProtocolFeatures(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v1/chain/get_activated_protocol_features", req.URL.String())
+
+		var params struct {
+			LowerBound *uint32 `json:"lower_bound"`
+		}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&params))
+
+		if params.LowerBound == nil {
+			_, _ = res.Write([]byte(`{"activated_protocol_features": [` +
+				testProtocolFeatureJSON(testPreactivateDigest, 0, 4, "PREACTIVATE_FEATURE") + `,` +
+				testProtocolFeatureJSON(testBillFirstDigest, 1, 10, "ONLY_BILL_FIRST_AUTHORIZER") +
+				`], "more": 2}`))
+		} else {
+			assert.Equal(t, uint32(2), *params.LowerBound)
+			_, _ = res.Write([]byte(`{"activated_protocol_features": [` +
+				testProtocolFeatureJSON(testGetBlockNumDigest, 2, 12, "GET_BLOCK_NUM") +
+				`]}`))
+		}
+	}))
+
+	client := New(srv.URL)
+	features, err := client.GetProtocolFeatures(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, features.Len())
+	assert.True(t, features.IsActivated("PREACTIVATE_FEATURE"))
+	assert.True(t, features.IsActivated("ONLY_BILL_FIRST_AUTHORIZER"))
+	assert.True(t, features.IsActivated("GET_BLOCK_NUM"))
+	assert.False(t, features.IsActivated("CRYPTO_PRIMITIVES"))
+	assert.True(t, features.IsDigestActivated(testGetBlockNumDigest))
+	assert.False(t, features.IsDigestActivated("abc"))
+
+	f, ok := features.Get("GET_BLOCK_NUM")
+	require.True(t, ok)
+	assert.Equal(t, uint32(12), f.ActivationBlockNum)
+
+	f, ok = features.GetByDigest(testBillFirstDigest)
+	require.True(t, ok)
+	assert.Equal(t, "ONLY_BILL_FIRST_AUTHORIZER", f.Codename())
+}
+
+func TestProtocolFeaturesIteratorReverse(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+
+		requests++
+		switch requests {
+		case 1:
+			assert.JSONEq(t, `{"limit": 2, "reverse": true}`, string(body))
+			_, _ = res.Write([]byte(`{"activated_protocol_features": [` +
+				testProtocolFeatureJSON(testGetBlockNumDigest, 2, 12, "GET_BLOCK_NUM") + `,` +
+				testProtocolFeatureJSON(testBillFirstDigest, 1, 10, "ONLY_BILL_FIRST_AUTHORIZER") +
+				`], "more": 0}`))
+		case 2:
+			// upper_bound 0 must be sent.
+			assert.JSONEq(t, `{"upper_bound": 0, "limit": 2, "reverse": true}`, string(body))
+			_, _ = res.Write([]byte(`{"activated_protocol_features": [` +
+				testProtocolFeatureJSON(testPreactivateDigest, 0, 4, "PREACTIVATE_FEATURE") +
+				`]}`))
+		default:
+			t.Errorf("unexpected request %s", body)
+			res.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+
+	client := New(srv.URL)
+	it := client.NewProtocolFeaturesIterator(ProtocolFeaturesRequest{Limit: 2, Reverse: true})
+
+	ordinals := []uint32{}
+	for it.Next(context.Background()) {
+		ordinals = append(ordinals, it.Feature().ActivationOrdinal)
+	}
+
+	require.NoError(t, it.Err())
+	assert.Equal(t, []uint32{2, 1, 0}, ordinals)
+	assert.Equal(t, 2, requests)
+}
+
+func TestGetProtocolFeaturesError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.WriteHeader(http.StatusNotFound)
+	}))
+
+	client := New(srv.URL)
+	_, err := client.GetProtocolFeatures(context.Background())
+	assert.Equal(t, HTTPError{Code: 404}, err)
+}
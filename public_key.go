@@ -0,0 +1,84 @@
+package leapapi
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// PublicKey is an antelope public key.
+type PublicKey struct {
+	Type KeyType
+	// Compressed public key (33 bytes) for K1 and R1 keys.
+	// WA keys also include user presence and relying party id.
+	Data []byte
+}
+
+// ParsePublicKey parses a public key in either legacy ("EOS...")
+// or new ("PUB_K1_...", "PUB_R1_...", "PUB_WA_...") format.
+func ParsePublicKey(s string) (PublicKey, error) {
+	t, data, err := decodePublicKeyString(s)
+	if err != nil {
+		return PublicKey{}, err
+	}
+	return PublicKey{Type: t, Data: data}, nil
+}
+
+// IsEmpty returns true if the key is the zero value.
+func (k PublicKey) IsEmpty() bool {
+	return len(k.Data) < 1
+}
+
+// String returns the key in the same format as nodeos
+// (legacy format for K1 keys and new format for other types).
+func (k PublicKey) String() string {
+	if k.IsEmpty() {
+		return ""
+	}
+	return encodePublicKeyString(k.Type, k.Data)
+}
+
+// LegacyString returns the key in legacy "EOS..." format (K1 keys only).
+func (k PublicKey) LegacyString() (string, error) {
+	if k.Type != KeyTypeK1 {
+		return "", fmt.Errorf("public key: %s keys have no legacy format", k.Type)
+	}
+	return k.String(), nil
+}
+
+// NewFormatString returns the key in "PUB_<type>_..." format.
+func (k PublicKey) NewFormatString() string {
+	return "PUB_" + k.Type.String() + "_" + encodeKeyData(k.Data, k.Type.String())
+}
+
+// Equal returns true if k and o are the same key.
+func (k PublicKey) Equal(o PublicKey) bool {
+	return k.Type == o.Type && bytes.Equal(k.Data, o.Data)
+}
+
+// Verify returns true if sig is a valid signature of digest by this key.
+func (k PublicKey) Verify(digest []byte, sig Signature) bool {
+	pub, err := sig.RecoverPublicKey(digest)
+	return err == nil && k.Equal(pub)
+}
+
+func (k PublicKey) MarshalJSON() ([]byte, error) {
+	return json.Marshal(k.String())
+}
+
+func (k *PublicKey) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	if len(s) < 1 {
+		*k = PublicKey{}
+		return nil
+	}
+
+	key, err := ParsePublicKey(s)
+	if err == nil {
+		*k = key
+	}
+	return err
+}
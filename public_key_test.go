@@ -0,0 +1,161 @@
+package leapapi
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustParsePublicKey(s string) PublicKey {
+	key, err := ParsePublicKey(s)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+func TestParsePublicKey(t *testing.T) {
+	for _, s := range []string{testPublicKey, testPublicKeyK1} {
+		key, err := ParsePublicKey(s)
+		require.NoError(t, err)
+		assert.Equal(t, KeyTypeK1, key.Type)
+		assert.Len(t, key.Data, 33)
+		assert.Equal(t, testPublicKey, key.String())
+		assert.Equal(t, testPublicKeyK1, key.NewFormatString())
+
+		legacy, err := key.LegacyString()
+		require.NoError(t, err)
+		assert.Equal(t, testPublicKey, legacy)
+	}
+
+	// Same data as an R1 key.
+	r1 := PublicKey{Type: KeyTypeR1, Data: mustParsePublicKey(testPublicKey).Data}
+	key, err := ParsePublicKey(r1.String())
+	require.NoError(t, err)
+	assert.True(t, r1.Equal(key))
+	assert.Regexp(t, "^PUB_R1_", r1.String())
+	assert.Equal(t, r1.String(), r1.NewFormatString())
+
+	_, err = r1.LegacyString()
+	assert.EqualError(t, err, "public key: R1 keys have no legacy format")
+
+	_, err = ParsePublicKey("EOS6MRyAjQq8ud7hVNYcfnVPJqcVpscN5So8BhtHuGYqET5GDW5CW")
+	assert.EqualError(t, err, "public key: checksum mismatch")
+
+	_, err = ParsePublicKey("PUB_K1_6MRyAjQq8ud7hVNYcfnVPJqcVpscN5So8BhtHuGYqET5GDW5CV")
+	assert.EqualError(t, err, "public key: checksum mismatch")
+}
+
+func TestPublicKey_JSON(t *testing.T) {
+	var v struct {
+		Key PublicKey `json:"key"`
+	}
+
+	err := json.Unmarshal([]byte(`{"key": "`+testPublicKeyK1+`"}`), &v)
+	require.NoError(t, err)
+	assert.Equal(t, mustParsePublicKey(testPublicKey), v.Key)
+
+	payload, err := json.Marshal(v)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"key": "`+testPublicKey+`"}`, string(payload))
+
+	err = json.Unmarshal([]byte(`{"key": "EOS1"}`), &v)
+	assert.Error(t, err)
+
+	err = json.Unmarshal([]byte(`{"key": ""}`), &v)
+	require.NoError(t, err)
+	assert.True(t, v.Key.IsEmpty())
+}
+
+func TestSignature_K1(t *testing.T) {
+	key, err := ParsePrivateKey(testWIF)
+	require.NoError(t, err)
+
+	digest := sha256.Sum256([]byte("leapapi"))
+	sig, err := key.Sign(digest[:])
+	require.NoError(t, err)
+
+	parsed, err := ParseSignature(sig.String())
+	require.NoError(t, err)
+	assert.Equal(t, sig, parsed)
+
+	pub, err := sig.RecoverPublicKey(digest[:])
+	require.NoError(t, err)
+	assert.Equal(t, testPublicKey, pub.String())
+	assert.True(t, key.PublicKey().Verify(digest[:], sig))
+
+	other := sha256.Sum256([]byte("other"))
+	assert.False(t, key.PublicKey().Verify(other[:], sig))
+
+	payload, err := json.Marshal(sig)
+	require.NoError(t, err)
+	assert.Equal(t, `"`+sig.String()+`"`, string(payload))
+
+	var decoded Signature
+	require.NoError(t, json.Unmarshal(payload, &decoded))
+	assert.Equal(t, sig, decoded)
+}
+
+// Create a compact R1 signature with the standard library.
+func signR1(t *testing.T, key *ecdsa.PrivateKey, digest []byte) Signature {
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest)
+	require.NoError(t, err)
+
+	expected := elliptic.MarshalCompressed(elliptic.P256(), key.X, key.Y)
+	for code := byte(0); code < 4; code++ {
+		data := make([]byte, 65)
+		data[0] = 27 + 4 + code
+		r.FillBytes(data[1:33])
+		s.FillBytes(data[33:65])
+
+		if pub, err := recoverR1(data, digest); err == nil && string(pub) == string(expected) {
+			return Signature{Type: KeyTypeR1, Data: data}
+		}
+	}
+
+	t.Fatal("failed to find recovery code")
+	return Signature{}
+}
+
+func TestSignature_R1(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	pub := PublicKey{Type: KeyTypeR1, Data: elliptic.MarshalCompressed(elliptic.P256(), priv.X, priv.Y)}
+	digest := sha256.Sum256([]byte("leapapi"))
+	sig := signR1(t, priv, digest[:])
+
+	parsed, err := ParseSignature(sig.String())
+	require.NoError(t, err)
+	assert.Regexp(t, "^SIG_R1_", sig.String())
+	assert.Equal(t, sig, parsed)
+
+	recovered, err := sig.RecoverPublicKey(digest[:])
+	require.NoError(t, err)
+	assert.Equal(t, pub, recovered)
+	assert.True(t, pub.Verify(digest[:], sig))
+
+	other := sha256.Sum256([]byte("other"))
+	assert.False(t, pub.Verify(other[:], sig))
+}
+
+func TestSignature_RecoverErrors(t *testing.T) {
+	digest := sha256.Sum256([]byte("leapapi"))
+
+	_, err := Signature{Type: KeyTypeK1, Data: make([]byte, 65)}.RecoverPublicKey([]byte{1})
+	assert.EqualError(t, err, "signature: digest must be 32 bytes")
+
+	_, err = Signature{Type: KeyTypeWA, Data: make([]byte, 65)}.RecoverPublicKey(digest[:])
+	assert.EqualError(t, err, "signature: recovery is not supported for WA signatures")
+
+	_, err = Signature{Type: KeyTypeR1, Data: make([]byte, 65)}.RecoverPublicKey(digest[:])
+	assert.EqualError(t, err, "signature: invalid recovery code 0")
+
+	_, err = ParseSignature("SIG_K1_abc")
+	assert.EqualError(t, err, "signature: key data too short")
+}
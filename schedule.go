@@ -0,0 +1,74 @@
+package leapapi
+
+import (
+	"fmt"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Producer key used in legacy producer schedules.
+type ProducerKey struct {
+	ProducerName    Name      `json:"producer_name"`
+	BlockSigningKey PublicKey `json:"block_signing_key"`
+}
+
+// Legacy producer schedule (new_producers field in block headers)
+type LegacyProducerSchedule struct {
+	Version   uint32        `json:"version"`
+	Producers []ProducerKey `json:"producers"`
+}
+
+// Key and weight pair.
+type KeyWeight struct {
+	Key    PublicKey `json:"key"`
+	Weight uint16    `json:"weight"`
+}
+
+// Block signing authority. Encoded as a variant ([0, {...}]) in json
+// where 0 (block_signing_authority_v0) is the only type defined.
+type BlockSigningAuthority struct {
+	Threshold uint32      `json:"threshold"`
+	Keys      []KeyWeight `json:"keys"`
+}
+
+func (a BlockSigningAuthority) MarshalJSON() ([]byte, error) {
+	type v0 BlockSigningAuthority
+	return json.Marshal([]interface{}{0, v0(a)})
+}
+
+func (a *BlockSigningAuthority) UnmarshalJSON(b []byte) error {
+	type v0 BlockSigningAuthority
+	var r []jsoniter.RawMessage
+
+	err := json.Unmarshal(b, &r)
+	if err != nil {
+		return err
+	}
+
+	if len(r) != 2 {
+		return fmt.Errorf("block signing authority: expected 2 elements, got %d", len(r))
+	}
+
+	var t int
+	if err = json.Unmarshal(r[0], &t); err != nil {
+		return err
+	}
+
+	if t != 0 {
+		return fmt.Errorf("block signing authority: unknown type %d", t)
+	}
+
+	return json.Unmarshal(r[1], (*v0)(a))
+}
+
+// Producer authority used in producer schedules.
+type ProducerAuthority struct {
+	ProducerName Name                  `json:"producer_name"`
+	Authority    BlockSigningAuthority `json:"authority"`
+}
+
+// Producer authority schedule
+type ProducerAuthoritySchedule struct {
+	Version   uint32              `json:"version"`
+	Producers []ProducerAuthority `json:"producers"`
+}
@@ -0,0 +1,140 @@
+package leapapi
+
+import (
+	"crypto/elliptic"
+	"fmt"
+	"math/big"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+)
+
+// Signature is an antelope signature.
+type Signature struct {
+	Type KeyType
+	// Compact signature (recovery byte, r and s) for K1 and R1 signatures.
+	// WA signatures also include authenticator data and client json.
+	Data []byte
+}
+
+// ParseSignature parses a signature in "SIG_<type>_..." format.
+func ParseSignature(s string) (Signature, error) {
+	t, data, err := decodeSignatureString(s)
+	if err != nil {
+		return Signature{}, err
+	}
+	return Signature{Type: t, Data: data}, nil
+}
+
+func (s Signature) String() string {
+	if len(s.Data) < 1 {
+		return ""
+	}
+	return encodeSignatureString(s.Type, s.Data)
+}
+
+// RecoverPublicKey recovers the public key that signed digest.
+// Only K1 and R1 signatures are supported.
+func (s Signature) RecoverPublicKey(digest []byte) (PublicKey, error) {
+	if len(digest) != 32 {
+		return PublicKey{}, fmt.Errorf("signature: digest must be 32 bytes")
+	}
+
+	if len(s.Data) != 65 {
+		return PublicKey{}, fmt.Errorf("signature: invalid length %d", len(s.Data))
+	}
+
+	switch s.Type {
+	case KeyTypeK1:
+		pub, _, err := ecdsa.RecoverCompact(s.Data, digest)
+		if err != nil {
+			return PublicKey{}, fmt.Errorf("signature: %v", err)
+		}
+		return PublicKey{Type: KeyTypeK1, Data: pub.SerializeCompressed()}, nil
+	case KeyTypeR1:
+		data, err := recoverR1(s.Data, digest)
+		if err != nil {
+			return PublicKey{}, fmt.Errorf("signature: %v", err)
+		}
+		return PublicKey{Type: KeyTypeR1, Data: data}, nil
+	}
+	return PublicKey{}, fmt.Errorf("signature: recovery is not supported for %s signatures", s.Type)
+}
+
+// Recover a (compressed) P-256 public key from a compact signature.
+func recoverR1(sig []byte, hash []byte) ([]byte, error) {
+	curve := elliptic.P256()
+	params := curve.Params()
+
+	code := int(sig[0]) - 27
+	if code < 0 || code > 7 {
+		return nil, fmt.Errorf("invalid recovery code %d", sig[0])
+	}
+	code &= 3
+
+	r := new(big.Int).SetBytes(sig[1:33])
+	s := new(big.Int).SetBytes(sig[33:65])
+	if r.Sign() == 0 || s.Sign() == 0 || r.Cmp(params.N) >= 0 || s.Cmp(params.N) >= 0 {
+		return nil, fmt.Errorf("invalid signature")
+	}
+
+	// R = (x, y) where x = r (+ n if overflow)
+	x := new(big.Int).Set(r)
+	if code&2 != 0 {
+		x.Add(x, params.N)
+	}
+
+	if x.Cmp(params.P) >= 0 {
+		return nil, fmt.Errorf("invalid signature")
+	}
+
+	// y^2 = x^3 - 3x + b
+	y2 := new(big.Int).Exp(x, big.NewInt(3), params.P)
+	y2.Sub(y2, new(big.Int).Mul(x, big.NewInt(3)))
+	y2.Add(y2, params.B)
+	y2.Mod(y2, params.P)
+
+	y := new(big.Int).ModSqrt(y2, params.P)
+	if y == nil {
+		return nil, fmt.Errorf("invalid signature")
+	}
+
+	if y.Bit(0) != uint(code&1) {
+		y.Sub(params.P, y)
+	}
+
+	// Q = r^-1 (sR - eG)
+	e := new(big.Int).SetBytes(hash)
+	e.Neg(e).Mod(e, params.N)
+
+	sRx, sRy := curve.ScalarMult(x, y, s.Bytes())
+	eGx, eGy := curve.ScalarBaseMult(e.Bytes())
+	qx, qy := curve.Add(sRx, sRy, eGx, eGy)
+	qx, qy = curve.ScalarMult(qx, qy, new(big.Int).ModInverse(r, params.N).Bytes())
+
+	if qx.Sign() == 0 && qy.Sign() == 0 {
+		return nil, fmt.Errorf("invalid signature")
+	}
+	return elliptic.MarshalCompressed(curve, qx, qy), nil
+}
+
+func (s Signature) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+func (s *Signature) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+
+	if len(str) < 1 {
+		*s = Signature{}
+		return nil
+	}
+
+	sig, err := ParseSignature(str)
+	if err == nil {
+		*s = sig
+	}
+	return err
+}
@@ -0,0 +1,72 @@
+package leapapi
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// SignatureProvider provides signatures for transactions.
+type SignatureProvider interface {
+	// AvailableKeys returns the public keys that the provider can sign with.
+	AvailableKeys(ctx context.Context) ([]string, error)
+
+	// Sign signs a transaction for a chain with the private keys
+	// of the given public keys.
+	Sign(ctx context.Context, chainID string, tx Transaction, keys []string) ([]string, error)
+}
+
+// KeyBag is an in-memory SignatureProvider.
+type KeyBag struct {
+	// Private keys indexed by public key (PublicKey.String()).
+	keys map[string]*PrivateKey
+}
+
+// NewKeyBag creates a KeyBag from private key strings.
+func NewKeyBag(keys ...string) (*KeyBag, error) {
+	b := &KeyBag{keys: map[string]*PrivateKey{}}
+	for _, key := range keys {
+		if err := b.Add(key); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// Add parses and adds a private key to the bag.
+func (b *KeyBag) Add(key string) error {
+	k, err := ParsePrivateKey(key)
+	if err == nil {
+		b.keys[k.PublicKey().String()] = k
+	}
+	return err
+}
+
+// AvailableKeys returns the public keys of all keys in the bag.
+func (b *KeyBag) AvailableKeys(ctx context.Context) ([]string, error) {
+	keys := make([]string, 0, len(b.keys))
+	for pub := range b.keys {
+		keys = append(keys, pub)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// Sign signs the transaction with the private keys of keys.
+func (b *KeyBag) Sign(ctx context.Context, chainID string, tx Transaction, keys []string) ([]string, error) {
+	priv := make([]*PrivateKey, len(keys))
+	for i, pub := range keys {
+		// Normalize the key as it can be in either legacy or new format.
+		key, err := ParsePublicKey(pub)
+		if err != nil {
+			return nil, err
+		}
+
+		k, ok := b.keys[key.String()]
+		if !ok {
+			return nil, fmt.Errorf("key bag: no private key for %s", pub)
+		}
+		priv[i] = k
+	}
+	return tx.Sign(chainID, priv...)
+}
@@ -0,0 +1,148 @@
+package leapapi
+
+import (
+	"context"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPublicKeyK1 = "PUB_K1_6MRyAjQq8ud7hVNYcfnVPJqcVpscN5So8BhtHuGYqET5BoDq63"
+
+func TestKeyBag(t *testing.T) {
+	bag, err := NewKeyBag(testWIF)
+	require.NoError(t, err)
+
+	keys, err := bag.AvailableKeys(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{testPublicKey}, keys)
+
+	tx := testTransaction(t)
+	expected, err := tx.Sign(testChainID, mustParsePrivateKey(t, testWIF))
+	require.NoError(t, err)
+
+	// Both legacy and new key formats.
+	for _, pub := range []string{testPublicKey, testPublicKeyK1} {
+		sigs, err := bag.Sign(context.Background(), testChainID, tx, []string{pub})
+		require.NoError(t, err)
+		assert.Equal(t, expected, sigs)
+	}
+
+	_, err = bag.Sign(context.Background(), testChainID, tx, []string{"EOS5VnDAVDpvmKqK8TD8kMJnDW8GkBfGk6VKH4pPHSVCbbGLfDHVX"})
+	assert.EqualError(t, err, "public key: checksum mismatch")
+
+	_, err = NewKeyBag("invalid")
+	assert.Error(t, err)
+}
+
+func mustParsePrivateKey(t *testing.T, s string) *PrivateKey {
+	key, err := ParsePrivateKey(s)
+	require.NoError(t, err)
+	return key
+}
+
+func TestKeyBagMissingKey(t *testing.T) {
+	bag, err := NewKeyBag()
+	require.NoError(t, err)
+
+	_, err = bag.Sign(context.Background(), testChainID, testTransaction(t), []string{testPublicKey})
+	assert.EqualError(t, err, "key bag: no private key for "+testPublicKey)
+}
+
+func TestGetRequiredKeys(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v1/chain/get_required_keys", req.URL.String())
+
+		body, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+            "transaction": {
+                "expiration": "2018-06-08T08:08:08",
+                "ref_block_num": 19549,
+                "ref_block_prefix": 3569595041,
+                "max_net_usage_words": 0,
+                "max_cpu_usage_ms": 0,
+                "delay_sec": 0,
+                "context_free_actions": [],
+                "actions": [{
+                    "account": "eosio.token",
+                    "name": "transfer",
+                    "authorization": [{"actor": "alice", "permission": "active"}],
+                    "data": "`+testTransferTo+`"
+                }],
+                "transaction_extensions": []
+            },
+            "available_keys": ["`+testPublicKey+`", "`+testPublicKeyK1+`"]
+        }`, string(body))
+
+		_, _ = res.Write([]byte(`{"required_keys": ["` + testPublicKeyK1 + `"]}`))
+	}))
+
+	client := New(srv.URL)
+	keys, err := client.GetRequiredKeys(context.Background(), testTransaction(t), []string{testPublicKey, testPublicKeyK1})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{testPublicKeyK1}, keys)
+}
+
+func TestSignAndPushTransaction(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		switch req.URL.String() {
+		case "/v1/chain/get_info":
+			_, _ = res.Write([]byte(`{
+                "chain_id": "` + testChainID + `",
+                "head_block_id": "0a3b4c60ffffffffffffffff0000000000000000000000000000000000000000",
+                "head_block_time": "2018-06-08T08:07:38",
+                "last_irreversible_block_id": "0a3b4c5d11223344a1b2c3d40000000000000000000000000000000000000000"
+            }`))
+		case "/v1/chain/get_required_keys":
+			_, _ = res.Write([]byte(`{"required_keys": ["` + testPublicKeyK1 + `"]}`))
+		case "/v1/chain/push_transaction":
+			var body struct {
+				Signatures []string `json:"signatures"`
+				PackedTrx  string   `json:"packed_trx"`
+			}
+
+			data, err := ioutil.ReadAll(req.Body)
+			require.NoError(t, err)
+			require.NoError(t, json.Unmarshal(data, &body))
+			assert.Equal(t, testPackedTrx, body.PackedTrx)
+
+			// Signature is valid for the test key.
+			require.Len(t, body.Signatures, 1)
+			_, sig, err := decodeSignatureString(body.Signatures[0])
+			require.NoError(t, err)
+
+			digest, _ := hex.DecodeString(testTrxDigest)
+			pub, _, err := ecdsa.RecoverCompact(sig, digest)
+			require.NoError(t, err)
+			assert.Equal(t, testPublicKey, encodePublicKeyString(KeyTypeK1, pub.SerializeCompressed()))
+
+			_, _ = res.Write([]byte(`{"transaction_id": "` + testTrxID + `", "processed": {"id": "` + testTrxID + `"}}`))
+		default:
+			t.Errorf("unexpected request %s", req.URL)
+		}
+	}))
+
+	bag, err := NewKeyBag(testWIF)
+	require.NoError(t, err)
+
+	// Expiration and TaPoS is set from get_info.
+	tx := testTransaction(t)
+	tx.Expiration = time.Time{}
+	tx.RefBlockNum = 0
+	tx.RefBlockPrefix = 0
+
+	client := New(srv.URL)
+	trace, err := client.SignAndPushTransaction(context.Background(), tx, bag)
+
+	require.NoError(t, err)
+	assert.Equal(t, testTrxID, trace.ID)
+}
@@ -0,0 +1,426 @@
+package leapapi
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// skipPager keeps track of skip/limit paging for the hyperion iterators.
+type skipPager struct {
+	skip uint32
+	more bool
+}
+
+func newSkipPager(skip uint32) skipPager {
+	return skipPager{skip: skip, more: true}
+}
+
+// advance moves past a page of n rows.
+// Paging stops at an empty page or when total is reached. A short page is
+// not the end as hyperion caps the page size on the server side.
+func (p *skipPager) advance(n int, total HyperionTotal) {
+	p.skip += uint32(n)
+	p.more = n > 0
+	if total.Relation == "eq" && uint64(p.skip) >= total.Value {
+		p.more = false
+	}
+}
+
+// Hyperion token balance
+type HyperionToken struct {
+	Symbol    string  `json:"symbol"`
+	Precision uint8   `json:"precision"`
+	Amount    float64 `json:"amount"`
+	Contract  Name    `json:"contract"`
+}
+
+// /v2/state/get_tokens request parameters
+type TokensRequest struct {
+	Account Name
+	Skip    uint32
+	Limit   uint32
+}
+
+func (r TokensRequest) values() url.Values {
+	v := url.Values{}
+	v.Set("account", string(r.Account))
+	setSkipLimit(v, r.Skip, r.Limit)
+	return v
+}
+
+// /v2/state/get_tokens format
+type HyperionTokens struct {
+	QueryTime float32         `json:"query_time_ms"`
+	Account   Name            `json:"account"`
+	Tokens    []HyperionToken `json:"tokens"`
+}
+
+// TokensIterator iterates over all tokens of an account,
+// following skip/limit to fetch the next page when needed.
+type TokensIterator struct {
+	client *Client
+	req    TokensRequest
+	pager  skipPager
+	rows   []HyperionToken
+	row    HyperionToken
+	err    error
+}
+
+// NewTokensIterator creates a new iterator starting at req.
+func (c *Client) NewTokensIterator(req TokensRequest) *TokensIterator {
+	return &TokensIterator{
+		client: c,
+		req:    req,
+		pager:  newSkipPager(req.Skip),
+	}
+}
+
+// Next advances the iterator to the next token.
+// Returns false when there are no more tokens or an error occurred.
+func (it *TokensIterator) Next(ctx context.Context) bool {
+	for len(it.rows) < 1 {
+		if !it.pager.more || it.err != nil {
+			return false
+		}
+
+		it.req.Skip = it.pager.skip
+		res, err := it.client.GetTokens(ctx, it.req)
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.pager.advance(len(res.Tokens), HyperionTotal{})
+		it.rows = res.Tokens
+	}
+
+	it.row, it.rows = it.rows[0], it.rows[1:]
+	return true
+}
+
+// Token returns the current token.
+func (it *TokensIterator) Token() HyperionToken {
+	return it.row
+}
+
+// Err returns the error (if any) that stopped the iteration.
+func (it *TokensIterator) Err() error {
+	return it.err
+}
+
+// /v2/state/get_key_accounts format
+type KeyAccounts struct {
+	QueryTime    float32 `json:"query_time_ms"`
+	AccountNames []Name  `json:"account_names"`
+}
+
+// Hyperion permission link
+type HyperionLink struct {
+	BlockNum     uint32    `json:"block_num"`
+	Timestamp    time.Time `json:"timestamp" time_format:"2006-01-02T15:04:05.000"`
+	Account      Name      `json:"account"`
+	Permission   Name      `json:"permission"`
+	Code         Name      `json:"code"`
+	Action       Name      `json:"action"`
+	Irreversible bool      `json:"irreversible"`
+}
+
+// /v2/state/get_links request parameters
+//
+// Zero values are not sent.
+type LinksRequest struct {
+	Account    Name
+	Code       Name
+	Action     Name
+	Permission Name
+	Skip       uint32
+	Limit      uint32
+}
+
+func (r LinksRequest) values() url.Values {
+	v := url.Values{}
+	setNonEmpty(v, map[string]string{
+		"account":    string(r.Account),
+		"code":       string(r.Code),
+		"action":     string(r.Action),
+		"permission": string(r.Permission),
+	})
+	setSkipLimit(v, r.Skip, r.Limit)
+	return v
+}
+
+// /v2/state/get_links format
+type HyperionLinks struct {
+	QueryTime float32        `json:"query_time_ms"`
+	Total     HyperionTotal  `json:"total"`
+	Links     []HyperionLink `json:"links"`
+}
+
+// LinksIterator iterates over all links matching a request,
+// following skip/limit to fetch the next page when needed.
+type LinksIterator struct {
+	client *Client
+	req    LinksRequest
+	pager  skipPager
+	rows   []HyperionLink
+	row    HyperionLink
+	err    error
+}
+
+// NewLinksIterator creates a new iterator starting at req.
+func (c *Client) NewLinksIterator(req LinksRequest) *LinksIterator {
+	return &LinksIterator{
+		client: c,
+		req:    req,
+		pager:  newSkipPager(req.Skip),
+	}
+}
+
+// Next advances the iterator to the next link.
+// Returns false when there are no more links or an error occurred.
+func (it *LinksIterator) Next(ctx context.Context) bool {
+	for len(it.rows) < 1 {
+		if !it.pager.more || it.err != nil {
+			return false
+		}
+
+		it.req.Skip = it.pager.skip
+		res, err := it.client.GetLinks(ctx, it.req)
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.pager.advance(len(res.Links), res.Total)
+		it.rows = res.Links
+	}
+
+	it.row, it.rows = it.rows[0], it.rows[1:]
+	return true
+}
+
+// Link returns the current link.
+func (it *LinksIterator) Link() HyperionLink {
+	return it.row
+}
+
+// Err returns the error (if any) that stopped the iteration.
+func (it *LinksIterator) Err() error {
+	return it.err
+}
+
+// Proposal approval
+type ProposalApproval struct {
+	Actor      Name      `json:"actor"`
+	Permission Name      `json:"permission"`
+	Time       time.Time `json:"time" time_format:"2006-01-02T15:04:05.000"`
+}
+
+// Hyperion multisig proposal
+type HyperionProposal struct {
+	Proposer           Name               `json:"proposer"`
+	ProposalName       Name               `json:"proposal_name"`
+	RequestedApprovals []ProposalApproval `json:"requested_approvals"`
+	ProvidedApprovals  []ProposalApproval `json:"provided_approvals"`
+	Executed           bool               `json:"executed"`
+	PrimaryKey         string             `json:"primary_key"`
+	BlockNum           uint32             `json:"block_num"`
+}
+
+// /v2/state/get_proposals request parameters
+//
+// Zero values are not sent.
+type ProposalsRequest struct {
+	Proposer Name
+	Proposal Name
+	// Account in either requested or provided approvals
+	Account   Name
+	Requested Name
+	Provided  Name
+	Executed  *bool
+	Track     bool
+	Skip      uint32
+	Limit     uint32
+}
+
+func (r ProposalsRequest) values() url.Values {
+	v := url.Values{}
+	setNonEmpty(v, map[string]string{
+		"proposer":  string(r.Proposer),
+		"proposal":  string(r.Proposal),
+		"account":   string(r.Account),
+		"requested": string(r.Requested),
+		"provided":  string(r.Provided),
+	})
+
+	if r.Executed != nil {
+		v.Set("executed", strconv.FormatBool(*r.Executed))
+	}
+
+	if r.Track {
+		v.Set("track", "true")
+	}
+	setSkipLimit(v, r.Skip, r.Limit)
+	return v
+}
+
+// /v2/state/get_proposals format
+type HyperionProposals struct {
+	QueryTime float32            `json:"query_time_ms"`
+	Total     HyperionTotal      `json:"total"`
+	Proposals []HyperionProposal `json:"proposals"`
+}
+
+// ProposalsIterator iterates over all proposals matching a request,
+// following skip/limit to fetch the next page when needed.
+type ProposalsIterator struct {
+	client *Client
+	req    ProposalsRequest
+	pager  skipPager
+	rows   []HyperionProposal
+	row    HyperionProposal
+	err    error
+}
+
+// NewProposalsIterator creates a new iterator starting at req.
+func (c *Client) NewProposalsIterator(req ProposalsRequest) *ProposalsIterator {
+	return &ProposalsIterator{
+		client: c,
+		req:    req,
+		pager:  newSkipPager(req.Skip),
+	}
+}
+
+// Next advances the iterator to the next proposal.
+// Returns false when there are no more proposals or an error occurred.
+func (it *ProposalsIterator) Next(ctx context.Context) bool {
+	for len(it.rows) < 1 {
+		if !it.pager.more || it.err != nil {
+			return false
+		}
+
+		it.req.Skip = it.pager.skip
+		res, err := it.client.GetProposals(ctx, it.req)
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.pager.advance(len(res.Proposals), res.Total)
+		it.rows = res.Proposals
+	}
+
+	it.row, it.rows = it.rows[0], it.rows[1:]
+	return true
+}
+
+// Proposal returns the current proposal.
+func (it *ProposalsIterator) Proposal() HyperionProposal {
+	return it.row
+}
+
+// Err returns the error (if any) that stopped the iteration.
+func (it *ProposalsIterator) Err() error {
+	return it.err
+}
+
+// Hyperion voter
+type HyperionVoter struct {
+	Account  Name    `json:"account"`
+	Weight   float64 `json:"weight"`
+	LastVote uint32  `json:"last_vote"`
+}
+
+// /v2/state/get_voters request parameters
+//
+// Zero values are not sent.
+type VotersRequest struct {
+	Producer Name
+	// Only return proxies
+	Proxy bool
+	Skip  uint32
+	Limit uint32
+}
+
+func (r VotersRequest) values() url.Values {
+	v := url.Values{}
+	setNonEmpty(v, map[string]string{"producer": string(r.Producer)})
+
+	if r.Proxy {
+		v.Set("proxy", "true")
+	}
+	setSkipLimit(v, r.Skip, r.Limit)
+	return v
+}
+
+// /v2/state/get_voters format
+type HyperionVoters struct {
+	QueryTime float32         `json:"query_time_ms"`
+	Total     HyperionTotal   `json:"total"`
+	Voters    []HyperionVoter `json:"voters"`
+}
+
+// VotersIterator iterates over all voters matching a request,
+// following skip/limit to fetch the next page when needed.
+type VotersIterator struct {
+	client *Client
+	req    VotersRequest
+	pager  skipPager
+	rows   []HyperionVoter
+	row    HyperionVoter
+	err    error
+}
+
+// NewVotersIterator creates a new iterator starting at req.
+func (c *Client) NewVotersIterator(req VotersRequest) *VotersIterator {
+	return &VotersIterator{
+		client: c,
+		req:    req,
+		pager:  newSkipPager(req.Skip),
+	}
+}
+
+// Next advances the iterator to the next voter.
+// Returns false when there are no more voters or an error occurred.
+func (it *VotersIterator) Next(ctx context.Context) bool {
+	for len(it.rows) < 1 {
+		if !it.pager.more || it.err != nil {
+			return false
+		}
+
+		it.req.Skip = it.pager.skip
+		res, err := it.client.GetVoters(ctx, it.req)
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.pager.advance(len(res.Voters), res.Total)
+		it.rows = res.Voters
+	}
+
+	it.row, it.rows = it.rows[0], it.rows[1:]
+	return true
+}
+
+// Voter returns the current voter.
+func (it *VotersIterator) Voter() HyperionVoter {
+	return it.row
+}
+
+// Err returns the error (if any) that stopped the iteration.
+func (it *VotersIterator) Err() error {
+	return it.err
+}
+
+// /v2/state/get_account format
+type HyperionAccount struct {
+	QueryTime    float32          `json:"query_time_ms"`
+	Account      Account          `json:"account"`
+	Actions      []HyperionAction `json:"actions"`
+	TotalActions uint64           `json:"total_actions"`
+	Tokens       []HyperionToken  `json:"tokens"`
+	Links        []HyperionLink   `json:"links"`
+}
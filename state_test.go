@@ -0,0 +1,267 @@
+package leapapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokensIterator(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v2/state/get_tokens", req.URL.Path)
+		assert.Equal(t, "alice", req.URL.Query().Get("account"))
+		assert.Equal(t, "2", req.URL.Query().Get("limit"))
+
+		switch req.URL.Query().Get("skip") {
+		case "":
+			_, _ = res.Write([]byte(`{"query_time_ms": 1, "account": "alice", "tokens": [
+                {"symbol": "EOS", "precision": 4, "amount": 1.5, "contract": "eosio.token"},
+                {"symbol": "TLOS", "precision": 4, "amount": 2, "contract": "eosio.token"}
+            ]}`))
+		case "2":
+			_, _ = res.Write([]byte(`{"query_time_ms": 1, "account": "alice", "tokens": [
+                {"symbol": "BOX", "precision": 6, "amount": 0.25, "contract": "token.box"}
+            ]}`))
+		case "3":
+			_, _ = res.Write([]byte(`{"query_time_ms": 1, "account": "alice", "tokens": []}`))
+		default:
+			t.Errorf("unexpected skip %s", req.URL.Query().Get("skip"))
+		}
+	}))
+
+	client := New(srv.URL)
+	it := client.NewTokensIterator(TokensRequest{Account: "alice", Limit: 2})
+
+	tokens := []HyperionToken{}
+	for it.Next(context.Background()) {
+		tokens = append(tokens, it.Token())
+	}
+
+	require.NoError(t, it.Err())
+	assert.Equal(t, []HyperionToken{
+		{Symbol: "EOS", Precision: 4, Amount: 1.5, Contract: "eosio.token"},
+		{Symbol: "TLOS", Precision: 4, Amount: 2, Contract: "eosio.token"},
+		{Symbol: "BOX", Precision: 6, Amount: 0.25, Contract: "token.box"},
+	}, tokens)
+}
+
+func TestGetKeyAccounts(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v2/state/get_key_accounts?public_key="+testPublicKey, req.URL.String())
+		_, _ = res.Write([]byte(`{"query_time_ms": 2.5, "account_names": ["alice", "bob"]}`))
+	}))
+
+	client := New(srv.URL)
+	accounts, err := client.GetKeyAccounts(context.Background(), mustParsePublicKey(testPublicKeyK1))
+	require.NoError(t, err)
+	assert.Equal(t, KeyAccounts{QueryTime: 2.5, AccountNames: []Name{"alice", "bob"}}, accounts)
+}
+
+func TestLinksIterator(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v2/state/get_links", req.URL.Path)
+		assert.Equal(t, "alice", req.URL.Query().Get("account"))
+
+		link := `{
+            "block_num": 100,
+            "timestamp": "2018-06-08T08:08:08.500",
+            "account": "alice",
+            "permission": "transfer",
+            "code": "eosio.token",
+            "action": "transfer",
+            "irreversible": true
+        }`
+
+		// No limit given, so paging continues until total is reached.
+		switch req.URL.Query().Get("skip") {
+		case "":
+			_, _ = res.Write([]byte(`{"total": {"value": 3, "relation": "eq"}, "links": [` + link + `,` + link + `]}`))
+		case "2":
+			_, _ = res.Write([]byte(`{"total": {"value": 3, "relation": "eq"}, "links": [` + link + `]}`))
+		default:
+			t.Errorf("unexpected skip %s", req.URL.Query().Get("skip"))
+		}
+	}))
+
+	client := New(srv.URL)
+	it := client.NewLinksIterator(LinksRequest{Account: "alice"})
+
+	links := []HyperionLink{}
+	for it.Next(context.Background()) {
+		links = append(links, it.Link())
+	}
+
+	require.NoError(t, it.Err())
+	require.Len(t, links, 3)
+	assert.Equal(t, HyperionLink{
+		BlockNum:     100,
+		Timestamp:    time.Date(2018, 6, 8, 8, 8, 8, 500000000, time.UTC),
+		Account:      "alice",
+		Permission:   "transfer",
+		Code:         "eosio.token",
+		Action:       "transfer",
+		Irreversible: true,
+	}, links[0])
+}
+
+func TestLinksIteratorServerLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "1000", req.URL.Query().Get("limit"))
+
+		link := `{"block_num": 100, "timestamp": "2018-06-08T08:08:08.500", "account": "alice"}`
+
+		// Server caps the page size at 2, paging continues until total is reached.
+		switch req.URL.Query().Get("skip") {
+		case "":
+			_, _ = res.Write([]byte(`{"total": {"value": 5, "relation": "eq"}, "links": [` + link + `,` + link + `]}`))
+		case "2":
+			_, _ = res.Write([]byte(`{"total": {"value": 5, "relation": "eq"}, "links": [` + link + `,` + link + `]}`))
+		case "4":
+			_, _ = res.Write([]byte(`{"total": {"value": 5, "relation": "eq"}, "links": [` + link + `]}`))
+		default:
+			t.Errorf("unexpected skip %s", req.URL.Query().Get("skip"))
+		}
+	}))
+
+	client := New(srv.URL)
+	it := client.NewLinksIterator(LinksRequest{Account: "alice", Limit: 1000})
+
+	n := 0
+	for it.Next(context.Background()) {
+		n++
+	}
+
+	require.NoError(t, it.Err())
+	assert.Equal(t, 5, n)
+}
+
+func TestGetProposals(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v2/state/get_proposals?executed=false&limit=10&proposer=alice&track=true", req.URL.String())
+
+		payload := `{
+            "query_time_ms": 3,
+            "total": {"value": 1, "relation": "eq"},
+            "proposals": [{
+                "proposer": "alice",
+                "proposal_name": "upgrade",
+                "requested_approvals": [{"actor": "bob", "permission": "active", "time": "1970-01-01T00:00:00.000"}],
+                "provided_approvals": [{"actor": "carol", "permission": "active", "time": "2018-06-08T08:08:08.500"}],
+                "executed": false,
+                "primary_key": "15938991981558759424",
+                "block_num": 100
+            }]
+        }`
+		_, _ = res.Write([]byte(payload))
+	}))
+
+	executed := false
+	client := New(srv.URL)
+	proposals, err := client.GetProposals(context.Background(), ProposalsRequest{
+		Proposer: "alice",
+		Executed: &executed,
+		Track:    true,
+		Limit:    10,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, HyperionTotal{Value: 1, Relation: "eq"}, proposals.Total)
+	assert.Equal(t, []HyperionProposal{{
+		Proposer:     "alice",
+		ProposalName: "upgrade",
+		RequestedApprovals: []ProposalApproval{
+			{Actor: "bob", Permission: "active", Time: time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		ProvidedApprovals: []ProposalApproval{
+			{Actor: "carol", Permission: "active", Time: time.Date(2018, 6, 8, 8, 8, 8, 500000000, time.UTC)},
+		},
+		PrimaryKey: "15938991981558759424",
+		BlockNum:   100,
+	}}, proposals.Proposals)
+}
+
+func TestVotersIterator(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v2/state/get_voters", req.URL.Path)
+		assert.Equal(t, "prod1", req.URL.Query().Get("producer"))
+		assert.Equal(t, "true", req.URL.Query().Get("proxy"))
+
+		switch req.URL.Query().Get("skip") {
+		case "":
+			_, _ = res.Write([]byte(`{"total": {"value": 10000, "relation": "gte"}, "voters": [
+                {"account": "alice", "weight": 1000.5, "last_vote": 100},
+                {"account": "bob", "weight": 10, "last_vote": 200}
+            ]}`))
+		case "2":
+			_, _ = res.Write([]byte(`{"total": {"value": 10000, "relation": "gte"}, "voters": []}`))
+		default:
+			t.Errorf("unexpected skip %s", req.URL.Query().Get("skip"))
+		}
+	}))
+
+	client := New(srv.URL)
+	it := client.NewVotersIterator(VotersRequest{Producer: "prod1", Proxy: true, Limit: 2})
+
+	voters := []HyperionVoter{}
+	for it.Next(context.Background()) {
+		voters = append(voters, it.Voter())
+	}
+
+	require.NoError(t, it.Err())
+	assert.Equal(t, []HyperionVoter{
+		{Account: "alice", Weight: 1000.5, LastVote: 100},
+		{Account: "bob", Weight: 10, LastVote: 200},
+	}, voters)
+}
+
+func TestVotersIteratorError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.WriteHeader(http.StatusInternalServerError)
+	}))
+
+	client := New(srv.URL)
+	it := client.NewVotersIterator(VotersRequest{})
+
+	assert.False(t, it.Next(context.Background()))
+	assert.Equal(t, HTTPError{Code: 500}, it.Err())
+}
+
+func TestGetHyperionAccount(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v2/state/get_account?account=alice", req.URL.String())
+
+		payload := `{
+            "query_time_ms": 20.5,
+            "account": {
+                "account_name": "alice",
+                "created": "2018-06-08T08:08:08.500",
+                "net_weight": "50000000000",
+                "permissions": []
+            },
+            "actions": [` + testHyperionAction + `],
+            "total_actions": 1,
+            "tokens": [{"symbol": "EOS", "precision": 4, "amount": 1.5, "contract": "eosio.token"}],
+            "links": []
+        }`
+		_, _ = res.Write([]byte(payload))
+	}))
+
+	client := New(srv.URL)
+	account, err := client.GetHyperionAccount(context.Background(), "alice")
+	require.NoError(t, err)
+
+	assert.Equal(t, float32(20.5), account.QueryTime)
+	assert.Equal(t, Name("alice"), account.Account.AccountName)
+	assert.Equal(t, time.Date(2018, 6, 8, 8, 8, 8, 500000000, time.UTC), account.Account.Created)
+	assert.Equal(t, Int64(50000000000), account.Account.NETWeight)
+	require.Len(t, account.Actions, 1)
+	assert.Equal(t, Name("transfer"), account.Actions[0].Act.Name)
+	assert.Equal(t, uint64(1), account.TotalActions)
+	assert.Equal(t, []HyperionToken{{Symbol: "EOS", Precision: 4, Amount: 1.5, Contract: "eosio.token"}}, account.Tokens)
+	assert.Empty(t, account.Links)
+}
@@ -0,0 +1,638 @@
+package leapapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Stream field filter
+type StreamFilter struct {
+	Field string `json:"field"`
+	Value string `json:"value"`
+}
+
+// action_stream_request parameters
+//
+// StartFrom/ReadUntil are block numbers, 0 means live/forever
+// and negative values are relative to the head block.
+// Contract, Action and Account can be "*".
+type ActionStreamRequest struct {
+	Contract  string         `json:"contract"`
+	Action    string         `json:"action"`
+	Account   string         `json:"account"`
+	StartFrom int64          `json:"start_from"`
+	ReadUntil int64          `json:"read_until"`
+	Filters   []StreamFilter `json:"filters"`
+	// "and" or "or" (default)
+	FilterOp string `json:"filter_op,omitempty"`
+}
+
+// delta_stream_request parameters
+//
+// StartFrom/ReadUntil works as for ActionStreamRequest.
+type DeltaStreamRequest struct {
+	Code      string `json:"code"`
+	Table     string `json:"table"`
+	Scope     string `json:"scope"`
+	Payer     string `json:"payer"`
+	StartFrom int64  `json:"start_from"`
+	ReadUntil int64  `json:"read_until"`
+}
+
+// Type of a stream message.
+type StreamMessageType string
+
+const (
+	StreamActionTrace StreamMessageType = "action_trace"
+	StreamDeltaTrace  StreamMessageType = "delta_trace"
+	StreamForkEvent   StreamMessageType = "fork_event"
+	StreamLibUpdate   StreamMessageType = "lib_update"
+)
+
+// Microfork, blocks StartingBlock to EndingBlock were replaced.
+type ForkEvent struct {
+	ChainID       string `json:"chain_id"`
+	StartingBlock uint32 `json:"starting_block"`
+	EndingBlock   uint32 `json:"ending_block"`
+	NewID         string `json:"new_id"`
+}
+
+// Last irreversible block update
+type LibUpdate struct {
+	ChainID  string `json:"chain_id"`
+	BlockNum uint32 `json:"block_num"`
+	BlockID  string `json:"block_id"`
+}
+
+// Message received on a stream.
+// Only the field matching Type is set.
+type StreamMessage struct {
+	Type StreamMessageType
+	// "live" or "history" for action and delta traces.
+	Mode   string
+	Action *HyperionAction
+	Delta  *HyperionDelta
+	Fork   *ForkEvent
+	LIB    *LibUpdate
+}
+
+// A stream request rejected by the server. This stops the stream.
+type streamRequestError struct {
+	event   string
+	message string
+}
+
+func (e streamRequestError) Error() string {
+	return fmt.Sprintf("stream: %s failed: %s", e.event, e.message)
+}
+
+var errStreamClosed = errors.New("stream: closed by server")
+
+// Keeps track of the last block seen on a stream, so the stream can be
+// resumed after reconnecting without delivering the same message twice.
+type streamResume struct {
+	block uint32
+	seen  map[string]bool
+}
+
+// add records key in block and returns false if it was already seen.
+func (r *streamResume) add(block uint32, key string) bool {
+	if block < r.block {
+		return true
+	}
+
+	if block > r.block || r.seen == nil {
+		r.block = block
+		r.seen = map[string]bool{}
+	}
+
+	if r.seen[key] {
+		return false
+	}
+	r.seen[key] = true
+	return true
+}
+
+// rollback forgets everything from block and onwards.
+func (r *streamResume) rollback(block uint32) {
+	if block > 0 && r.block >= block {
+		r.block = block - 1
+		r.seen = nil
+	}
+}
+
+// startFrom returns the block to resume from or start if nothing was seen.
+func (r *streamResume) startFrom(start int64) int64 {
+	if r.block > 0 {
+		return int64(r.block)
+	}
+	return start
+}
+
+// Wildcard match of a stream request field ("" and "*" matches anything).
+func streamMatch(pattern string, value Name) bool {
+	return pattern == "" || pattern == "*" || Name(pattern) == value
+}
+
+// Match a filter against a decoded trace. Arrays match if any element matches.
+func matchStreamFilter(v interface{}, path []string, value string) bool {
+	switch t := v.(type) {
+	case []interface{}:
+		for _, e := range t {
+			if matchStreamFilter(e, path, value) {
+				return true
+			}
+		}
+		return false
+	case map[string]interface{}:
+		if len(path) < 1 {
+			return false
+		}
+		return matchStreamFilter(t[path[0]], path[1:], value)
+	}
+	return len(path) < 1 && v != nil && fmt.Sprint(v) == value
+}
+
+// matches returns true if action (decoded as doc) matches the request.
+func (r ActionStreamRequest) matches(action HyperionAction, doc map[string]interface{}) bool {
+	if !streamMatch(r.Contract, action.Act.Account) || !streamMatch(r.Action, action.Act.Name) {
+		return false
+	}
+
+	if r.Account != "" && r.Account != "*" {
+		notified := false
+		for _, receipt := range action.Receipts {
+			notified = notified || string(receipt.Receiver) == r.Account
+		}
+
+		for _, auth := range action.Act.Authorization {
+			notified = notified || string(auth.Actor) == r.Account
+		}
+
+		if !notified {
+			return false
+		}
+	}
+
+	if len(r.Filters) < 1 {
+		return true
+	}
+
+	and := r.FilterOp == "and"
+	for _, f := range r.Filters {
+		path := strings.Split(f.Field, ".")
+		// "@<action>.<field>" is short for "act.data.<field>".
+		if _, ok := doc[path[0]]; !ok && path[0] == "@"+string(action.Act.Name) {
+			path = append([]string{"act", "data"}, path[1:]...)
+		}
+
+		if matchStreamFilter(doc, path, f.Value) != and {
+			return !and
+		}
+	}
+	return and
+}
+
+// matches returns true if delta matches the request.
+func (r DeltaStreamRequest) matches(delta HyperionDelta) bool {
+	return streamMatch(r.Code, delta.Code) &&
+		streamMatch(r.Table, delta.Table) &&
+		streamMatch(r.Scope, Name(delta.Scope)) &&
+		streamMatch(r.Payer, delta.Payer)
+}
+
+// Record a message in the resume state of the requests it belongs to (given
+// by match). Returns false if all of them have already seen it.
+//
+// Hyperion does not tell which request a message is for. If no request
+// matches, a single request is assumed to be the one, otherwise the message
+// is delivered without being recorded.
+func addStreamMessage(resume []streamResume, match func(i int) bool, block uint32, key string) bool {
+	indices := []int{}
+	for i := range resume {
+		if match(i) {
+			indices = append(indices, i)
+		}
+	}
+
+	if len(indices) < 1 && len(resume) == 1 {
+		indices = append(indices, 0)
+	}
+
+	if len(indices) < 1 {
+		return true
+	}
+
+	ok := false
+	for _, i := range indices {
+		if resume[i].add(block, key) {
+			ok = true
+		}
+	}
+	return ok
+}
+
+// Stream is a client for the hyperion streaming api (socket.io).
+//
+// The connection is reestablished if lost, resuming each request
+// from the last block seen by it.
+//
+//	s := leapapi.NewStream("https://hyperion.example.com")
+//	s.RequestActions(leapapi.ActionStreamRequest{Contract: "eosio.token", Action: "transfer"})
+//	for msg := range s.Start(ctx) {
+//		...
+//	}
+//	if err := s.Err(); err != nil {
+//		...
+//	}
+type Stream struct {
+	Url string
+	// socket.io path
+	Path string
+	// Delay before reconnecting after the connection is lost.
+	ReconnectDelay time.Duration
+
+	actionRequests []ActionStreamRequest
+	deltaRequests  []DeltaStreamRequest
+	// Resume state of each request (same index as the requests).
+	actions []streamResume
+	deltas  []streamResume
+	err     error
+}
+
+// NewStream creates a new stream for the hyperion api at url.
+func NewStream(url string) *Stream {
+	return &Stream{
+		Url:            url,
+		Path:           "/stream/",
+		ReconnectDelay: time.Second,
+	}
+}
+
+// RequestActions adds an action stream request.
+// Must be called before Start.
+func (s *Stream) RequestActions(req ActionStreamRequest) {
+	s.actionRequests = append(s.actionRequests, req)
+	s.actions = append(s.actions, streamResume{})
+}
+
+// RequestDeltas adds a delta stream request.
+// Must be called before Start.
+func (s *Stream) RequestDeltas(req DeltaStreamRequest) {
+	s.deltaRequests = append(s.deltaRequests, req)
+	s.deltas = append(s.deltas, streamResume{})
+}
+
+// Start connects to the server and delivers messages on the returned channel.
+//
+// The channel is closed when ctx is done or a request is rejected by the server
+// (Err returns the reason).
+func (s *Stream) Start(ctx context.Context) <-chan StreamMessage {
+	out := make(chan StreamMessage)
+
+	go func() {
+		defer close(out)
+
+		for {
+			err := s.session(ctx, out)
+			if ctx.Err() != nil {
+				return
+			}
+
+			if _, ok := err.(streamRequestError); ok {
+				s.err = err
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(s.ReconnectDelay):
+			}
+		}
+	}()
+
+	return out
+}
+
+// Err returns the error (if any) that stopped the stream.
+// Only valid after the channel returned by Start is closed.
+func (s *Stream) Err() error {
+	return s.err
+}
+
+// engine.io websocket url
+func (s *Stream) socketURL() (string, error) {
+	u, err := url.Parse(s.Url)
+	if err != nil {
+		return "", err
+	}
+
+	switch u.Scheme {
+	case "http":
+		u.Scheme = "ws"
+	case "https":
+		u.Scheme = "wss"
+	}
+
+	u.Path = strings.TrimRight(u.Path, "/") + s.Path
+	u.RawQuery = "EIO=4&transport=websocket"
+	return u.String(), nil
+}
+
+// Run a single connection until it is lost or ctx is done.
+func (s *Stream) session(ctx context.Context, out chan<- StreamMessage) error {
+	addr, err := s.socketURL()
+	if err != nil {
+		return err
+	}
+
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, addr, nil)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// Unblock reads when ctx is done.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = conn.Close()
+		case <-done:
+		}
+	}()
+
+	if err = s.handshake(conn); err != nil {
+		return err
+	}
+
+	pending := map[int]string{}
+	for i, req := range s.actionRequests {
+		req.StartFrom = s.actions[i].startFrom(req.StartFrom)
+		if req.Filters == nil {
+			req.Filters = []StreamFilter{}
+		}
+
+		if err = s.emit(conn, len(pending), "action_stream_request", req); err != nil {
+			return err
+		}
+		pending[len(pending)] = "action_stream_request"
+	}
+
+	for i, req := range s.deltaRequests {
+		req.StartFrom = s.deltas[i].startFrom(req.StartFrom)
+		if err = s.emit(conn, len(pending), "delta_stream_request", req); err != nil {
+			return err
+		}
+		pending[len(pending)] = "delta_stream_request"
+	}
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+
+		packet := string(data)
+		switch {
+		case packet == "2":
+			err = conn.WriteMessage(websocket.TextMessage, []byte("3"))
+		case packet == "1" || strings.HasPrefix(packet, "41"):
+			err = errStreamClosed
+		case strings.HasPrefix(packet, "43"):
+			err = s.handleAck(packet[2:], pending)
+		case strings.HasPrefix(packet, "42"):
+			err = s.handleEvent(ctx, packet[2:], out)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// engine.io open and socket.io connect to the default namespace.
+func (s *Stream) handshake(conn *websocket.Conn) error {
+	_, data, err := conn.ReadMessage()
+	if err != nil {
+		return err
+	}
+
+	if len(data) < 1 || data[0] != '0' {
+		return fmt.Errorf("stream: unexpected open packet %q", data)
+	}
+
+	if err = conn.WriteMessage(websocket.TextMessage, []byte("40")); err != nil {
+		return err
+	}
+
+	for {
+		_, data, err = conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+
+		packet := string(data)
+		switch {
+		case packet == "2":
+			err = conn.WriteMessage(websocket.TextMessage, []byte("3"))
+		case strings.HasPrefix(packet, "40"):
+			return nil
+		case strings.HasPrefix(packet, "44"):
+			return fmt.Errorf("stream: connect failed: %s", packet[2:])
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Emit a socket.io event with an ack id.
+func (s *Stream) emit(conn *websocket.Conn, id int, event string, v interface{}) error {
+	payload, err := json.Marshal([]interface{}{event, v})
+	if err != nil {
+		return err
+	}
+	return conn.WriteMessage(websocket.TextMessage, append([]byte("42"+strconv.Itoa(id)), payload...))
+}
+
+// Split a socket.io packet body into ack id (-1 if none) and json data.
+func splitPacketID(body string) (int, string) {
+	i := 0
+	for i < len(body) && body[i] >= '0' && body[i] <= '9' {
+		i++
+	}
+
+	if i < 1 {
+		return -1, body
+	}
+
+	id, _ := strconv.Atoi(body[:i])
+	return id, body[i:]
+}
+
+func (s *Stream) handleAck(body string, pending map[int]string) error {
+	id, data := splitPacketID(body)
+	event, ok := pending[id]
+	if !ok {
+		return nil
+	}
+
+	var res []struct {
+		Status string `json:"status"`
+		Error  string `json:"error"`
+	}
+
+	if err := json.Unmarshal([]byte(data), &res); err != nil {
+		return err
+	}
+
+	if len(res) > 0 && res[0].Status != "OK" {
+		msg := res[0].Error
+		if len(msg) < 1 {
+			msg = res[0].Status
+		}
+		return streamRequestError{event: event, message: msg}
+	}
+	return nil
+}
+
+func (s *Stream) handleEvent(ctx context.Context, body string, out chan<- StreamMessage) error {
+	_, data := splitPacketID(body)
+
+	var ev []jsoniter.RawMessage
+	if err := json.Unmarshal([]byte(data), &ev); err != nil {
+		return err
+	}
+
+	if len(ev) < 2 {
+		return nil
+	}
+
+	var name string
+	if err := json.Unmarshal(ev[0], &name); err != nil {
+		return err
+	}
+
+	msg, ok, err := s.decodeEvent(name, ev[1])
+	if err != nil || !ok {
+		return err
+	}
+
+	select {
+	case out <- msg:
+	case <-ctx.Done():
+	}
+	return nil
+}
+
+// Decode an event. Returns false if the event should not be delivered.
+func (s *Stream) decodeEvent(name string, data jsoniter.RawMessage) (msg StreamMessage, ok bool, err error) {
+	switch name {
+	case "message":
+		return s.decodeMessage(data)
+	case "fork_event":
+		var fork ForkEvent
+		if err = unmarshalEventData(data, &fork); err != nil {
+			return
+		}
+
+		for i := range s.actions {
+			s.actions[i].rollback(fork.StartingBlock)
+		}
+
+		for i := range s.deltas {
+			s.deltas[i].rollback(fork.StartingBlock)
+		}
+		return StreamMessage{Type: StreamForkEvent, Fork: &fork}, true, nil
+	case "lib_update":
+		var lib LibUpdate
+		if err = unmarshalEventData(data, &lib); err != nil {
+			return
+		}
+		return StreamMessage{Type: StreamLibUpdate, LIB: &lib}, true, nil
+	}
+	return
+}
+
+// Decode an action/delta trace from a "message" event.
+func (s *Stream) decodeMessage(data jsoniter.RawMessage) (msg StreamMessage, ok bool, err error) {
+	var m struct {
+		Type    StreamMessageType   `json:"type"`
+		Mode    string              `json:"mode"`
+		Message jsoniter.RawMessage `json:"message"`
+	}
+
+	if err = json.Unmarshal(data, &m); err != nil {
+		return
+	}
+
+	// Hyperion sends the trace as a json encoded string.
+	payload := []byte(m.Message)
+	if len(payload) > 0 && payload[0] == '"' {
+		var str string
+		if err = json.Unmarshal(payload, &str); err != nil {
+			return
+		}
+		payload = []byte(str)
+	}
+
+	msg = StreamMessage{Type: m.Type, Mode: m.Mode}
+	switch m.Type {
+	case StreamActionTrace:
+		var action HyperionAction
+		if err = json.Unmarshal(payload, &action); err != nil {
+			return
+		}
+
+		// Generic form of the trace, for matching request filters.
+		var doc map[string]interface{}
+		dec := json.NewDecoder(bytes.NewReader(payload))
+		dec.UseNumber()
+		if err = dec.Decode(&doc); err != nil {
+			return
+		}
+
+		key := strconv.FormatUint(uint64(action.GlobalSequence), 10)
+		ok = addStreamMessage(s.actions, func(i int) bool {
+			return s.actionRequests[i].matches(action, doc)
+		}, action.BlockNum, key)
+		msg.Action = &action
+	case StreamDeltaTrace:
+		var delta HyperionDelta
+		if err = json.Unmarshal(payload, &delta); err != nil {
+			return
+		}
+
+		key := strings.Join([]string{string(delta.Code), delta.Scope, string(delta.Table), delta.PrimaryKey, strconv.FormatBool(delta.Present)}, "/")
+		ok = addStreamMessage(s.deltas, func(i int) bool {
+			return s.deltaRequests[i].matches(delta)
+		}, delta.BlockNum, key)
+		msg.Delta = &delta
+	}
+	return
+}
+
+// Some hyperion versions wrap event data in a "data" object.
+func unmarshalEventData(data jsoniter.RawMessage, v interface{}) error {
+	var wrapped struct {
+		Data jsoniter.RawMessage `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &wrapped); err == nil && len(wrapped.Data) > 0 {
+		data = wrapped.Data
+	}
+	return json.Unmarshal(data, v)
+}
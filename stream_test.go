@@ -0,0 +1,323 @@
+package leapapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Minimal socket.io (engine.io v4) server standing in for hyperion.
+type testStreamServer struct {
+	t        *testing.T
+	mu       sync.Mutex
+	conns    int
+	requests []string
+	// Called for each connection after the stream requests are acked.
+	session func(n int, conn *websocket.Conn)
+	// Ack sent for stream requests.
+	ack string
+	// Number of stream requests per connection (default 1).
+	streamRequests int
+}
+
+func (s *testStreamServer) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	assert.Equal(s.t, "/stream/", req.URL.Path)
+	assert.Equal(s.t, "4", req.URL.Query().Get("EIO"))
+	assert.Equal(s.t, "websocket", req.URL.Query().Get("transport"))
+
+	upgrader := websocket.Upgrader{}
+	conn, err := upgrader.Upgrade(res, req, nil)
+	require.NoError(s.t, err)
+	defer conn.Close()
+
+	s.mu.Lock()
+	s.conns++
+	n := s.conns
+	s.mu.Unlock()
+
+	write := func(packet string) {
+		_ = conn.WriteMessage(websocket.TextMessage, []byte(packet))
+	}
+
+	read := func() string {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return ""
+		}
+		return string(data)
+	}
+
+	write(`0{"sid":"abc","upgrades":[],"pingInterval":25000,"pingTimeout":20000}`)
+	if read() != "40" {
+		return
+	}
+	write(`40{"sid":"def"}`)
+
+	for i := 0; i < s.streamRequests || i < 1; i++ {
+		packet := read()
+		s.mu.Lock()
+		s.requests = append(s.requests, packet)
+		s.mu.Unlock()
+
+		id, _ := splitPacketID(strings.TrimPrefix(packet, "42"))
+		write(fmt.Sprintf(`43%d[%s]`, id, s.ack))
+	}
+
+	// Ping must be answered.
+	write("2")
+	if read() != "3" {
+		return
+	}
+
+	s.session(n, conn)
+}
+
+func testStreamAction(block, seq int) string {
+	return testStreamContractAction("eosio.token", block, seq)
+}
+
+func testStreamContractAction(contract string, block, seq int) string {
+	action := fmt.Sprintf(`{"@timestamp": "2023-05-10T12:00:00.500", "block_num": %d, "global_sequence": %d, "act": {"account": "%s", "name": "transfer", "data": {"to": "bob"}}}`, block, seq, contract)
+	msg, _ := json.Marshal(map[string]interface{}{"type": "action_trace", "mode": "live", "message": action})
+	return `42["message",` + string(msg) + `]`
+}
+
+func TestStream(t *testing.T) {
+	done := make(chan struct{})
+	srv := httptest.NewServer(&testStreamServer{t: t, ack: `{"status":"OK"}`, session: func(n int, conn *websocket.Conn) {
+		write := func(packet string) {
+			_ = conn.WriteMessage(websocket.TextMessage, []byte(packet))
+		}
+
+		if n == 1 {
+			write(testStreamAction(10, 1))
+			write(testStreamAction(11, 2))
+			write(`42["lib_update",{"chain_id":"abc","block_num":9,"block_id":"0000000901"}]`)
+			// Drop the connection.
+			return
+		}
+
+		// Resumed from block 11, seq 2 was already delivered.
+		write(testStreamAction(11, 2))
+		write(testStreamAction(11, 3))
+		write(`42["fork_event",{"data":{"chain_id":"abc","starting_block":11,"ending_block":11,"new_id":"0000000b02"}}]`)
+		// Replayed after the fork.
+		write(testStreamAction(11, 2))
+		<-done
+	}})
+	defer close(done)
+
+	server := srv.Config.Handler.(*testStreamServer)
+
+	s := NewStream(srv.URL)
+	s.ReconnectDelay = time.Millisecond
+	s.RequestActions(ActionStreamRequest{Contract: "eosio.token", Action: "transfer", StartFrom: 1, Filters: []StreamFilter{{"act.data.to", "bob"}}})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	messages := []StreamMessage{}
+	for msg := range s.Start(ctx) {
+		messages = append(messages, msg)
+		if len(messages) == 6 {
+			cancel()
+		}
+	}
+
+	require.Len(t, messages, 6)
+	assert.NoError(t, s.Err())
+
+	seqs := []Uint64{}
+	for _, msg := range messages {
+		if msg.Type == StreamActionTrace {
+			assert.Equal(t, "live", msg.Mode)
+			assert.Equal(t, Name("transfer"), msg.Action.Act.Name)
+			seqs = append(seqs, msg.Action.GlobalSequence)
+		}
+	}
+	assert.Equal(t, []Uint64{1, 2, 3, 2}, seqs)
+
+	assert.Equal(t, StreamLibUpdate, messages[2].Type)
+	assert.Equal(t, &LibUpdate{ChainID: "abc", BlockNum: 9, BlockID: "0000000901"}, messages[2].LIB)
+
+	assert.Equal(t, StreamForkEvent, messages[4].Type)
+	assert.Equal(t, &ForkEvent{ChainID: "abc", StartingBlock: 11, EndingBlock: 11, NewID: "0000000b02"}, messages[4].Fork)
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	require.Len(t, server.requests, 2)
+	assert.Equal(t, `420["action_stream_request",{"contract":"eosio.token","action":"transfer","account":"","start_from":1,"read_until":0,"filters":[{"field":"act.data.to","value":"bob"}]}]`, server.requests[0])
+	assert.Equal(t, `420["action_stream_request",{"contract":"eosio.token","action":"transfer","account":"","start_from":11,"read_until":0,"filters":[{"field":"act.data.to","value":"bob"}]}]`, server.requests[1])
+}
+
+func TestStreamResumePerRequest(t *testing.T) {
+	done := make(chan struct{})
+	srv := httptest.NewServer(&testStreamServer{t: t, ack: `{"status":"OK"}`, streamRequests: 3, session: func(n int, conn *websocket.Conn) {
+		write := func(packet string) {
+			_ = conn.WriteMessage(websocket.TextMessage, []byte(packet))
+		}
+
+		if n == 1 {
+			// Request 0 is at block 200 and request 1 is behind at block 60.
+			write(testStreamContractAction("eosio.token", 200, 10))
+			write(testStreamContractAction("token.box", 60, 11))
+			return
+		}
+
+		write(testStreamContractAction("token.box", 60, 11))
+		write(testStreamContractAction("token.box", 61, 12))
+		<-done
+	}})
+	defer close(done)
+
+	server := srv.Config.Handler.(*testStreamServer)
+
+	s := NewStream(srv.URL)
+	s.ReconnectDelay = time.Millisecond
+	s.RequestActions(ActionStreamRequest{Contract: "eosio.token", Action: "*", StartFrom: 100})
+	s.RequestActions(ActionStreamRequest{Contract: "token.box", Action: "*", StartFrom: 50, Filters: []StreamFilter{{"@transfer.to", "bob"}}})
+	// Nothing seen, starts from the same block again.
+	s.RequestActions(ActionStreamRequest{Contract: "eosio", Action: "*", StartFrom: -10})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	seqs := []Uint64{}
+	for msg := range s.Start(ctx) {
+		seqs = append(seqs, msg.Action.GlobalSequence)
+		if len(seqs) == 3 {
+			cancel()
+		}
+	}
+	assert.Equal(t, []Uint64{10, 11, 12}, seqs)
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	require.Len(t, server.requests, 6)
+
+	startFrom := []int64{}
+	for _, packet := range server.requests {
+		var ev []jsoniter.RawMessage
+		require.NoError(t, json.Unmarshal([]byte(packet[3:]), &ev))
+
+		var req ActionStreamRequest
+		require.NoError(t, json.Unmarshal(ev[1], &req))
+		startFrom = append(startFrom, req.StartFrom)
+	}
+	assert.Equal(t, []int64{100, 50, -10, 200, 60, -10}, startFrom)
+}
+
+func TestActionStreamRequest_Matches(t *testing.T) {
+	payload := `{
+        "block_num": 10,
+        "act": {
+            "account": "eosio.token",
+            "name": "transfer",
+            "authorization": [{"actor": "alice", "permission": "active"}],
+            "data": {"from": "alice", "to": "bob", "amount": 10000}
+        },
+        "receipts": [{"receiver": "eosio.token"}, {"receiver": "bob"}]
+    }`
+
+	var action HyperionAction
+	require.NoError(t, json.Unmarshal([]byte(payload), &action))
+
+	var doc map[string]interface{}
+	dec := json.NewDecoder(strings.NewReader(payload))
+	dec.UseNumber()
+	require.NoError(t, dec.Decode(&doc))
+
+	tests := []struct {
+		name string
+		req  ActionStreamRequest
+		want bool
+	}{
+		{"wildcard", ActionStreamRequest{Contract: "*", Action: "*"}, true},
+		{"contract", ActionStreamRequest{Contract: "eosio", Action: "*"}, false},
+		{"action", ActionStreamRequest{Contract: "eosio.token", Action: "issue"}, false},
+		{"receiver", ActionStreamRequest{Account: "bob"}, true},
+		{"authorizer", ActionStreamRequest{Account: "alice"}, true},
+		{"not notified", ActionStreamRequest{Account: "carol"}, false},
+		{"filter", ActionStreamRequest{Filters: []StreamFilter{{"act.data.to", "bob"}}}, true},
+		{"filter shorthand", ActionStreamRequest{Filters: []StreamFilter{{"@transfer.to", "bob"}}}, true},
+		{"filter number", ActionStreamRequest{Filters: []StreamFilter{{"act.data.amount", "10000"}}}, true},
+		{"filter array", ActionStreamRequest{Filters: []StreamFilter{{"act.authorization.actor", "alice"}}}, true},
+		{"filter mismatch", ActionStreamRequest{Filters: []StreamFilter{{"act.data.to", "carol"}}}, false},
+		{"filter or", ActionStreamRequest{Filters: []StreamFilter{{"act.data.to", "carol"}, {"act.data.from", "alice"}}}, true},
+		{"filter and", ActionStreamRequest{FilterOp: "and", Filters: []StreamFilter{{"act.data.to", "carol"}, {"act.data.from", "alice"}}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.req.matches(action, doc))
+		})
+	}
+}
+
+func TestStreamDeltas(t *testing.T) {
+	srv := httptest.NewServer(&testStreamServer{t: t, ack: `{"status":"OK"}`, session: func(n int, conn *websocket.Conn) {
+		delta := `{"code":"eosio.token","scope":"alice","table":"accounts","primary_key":"5459781","payer":"alice","block_num":20,"present":true,"data":{"balance":"1.0000 EOS"}}`
+		msg, _ := json.Marshal(map[string]interface{}{"type": "delta_trace", "mode": "history", "message": delta})
+		_ = conn.WriteMessage(websocket.TextMessage, []byte(`42["message",`+string(msg)+`]`))
+		_, _, _ = conn.ReadMessage()
+	}})
+	server := srv.Config.Handler.(*testStreamServer)
+
+	s := NewStream(srv.URL)
+	s.RequestDeltas(DeltaStreamRequest{Code: "eosio.token", Table: "accounts", Scope: "alice"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	ch := s.Start(ctx)
+	msg := <-ch
+	cancel()
+	for range ch {
+	}
+
+	assert.Equal(t, StreamDeltaTrace, msg.Type)
+	assert.Equal(t, "history", msg.Mode)
+	require.NotNil(t, msg.Delta)
+	assert.Equal(t, Name("accounts"), msg.Delta.Table)
+	assert.Equal(t, uint32(20), msg.Delta.BlockNum)
+	assert.True(t, msg.Delta.Present)
+	assert.Equal(t, map[string]interface{}{"balance": "1.0000 EOS"}, msg.Delta.Data)
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	assert.Equal(t, `420["delta_stream_request",{"code":"eosio.token","table":"accounts","scope":"alice","payer":"","start_from":0,"read_until":0}]`, server.requests[0])
+}
+
+func TestStreamRequestRejected(t *testing.T) {
+	srv := httptest.NewServer(&testStreamServer{t: t, ack: `{"status":"ERROR","error":"invalid contract"}`, session: func(n int, conn *websocket.Conn) {
+		_, _, _ = conn.ReadMessage()
+	}})
+
+	s := NewStream(srv.URL)
+	s.RequestActions(ActionStreamRequest{Contract: "nope", Action: "*"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for range s.Start(ctx) {
+		t.Error("unexpected message")
+	}
+	assert.EqualError(t, s.Err(), "stream: action_stream_request failed: invalid contract")
+}
+
+func TestStream_SocketURL(t *testing.T) {
+	s := NewStream("https://hyperion.example.com/")
+	u, err := s.socketURL()
+	require.NoError(t, err)
+	assert.Equal(t, "wss://hyperion.example.com/stream/?EIO=4&transport=websocket", u)
+}
@@ -0,0 +1,202 @@
+package leapapi
+
+import (
+	"context"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// get_table_rows request parameters
+type TableRowsRequest struct {
+	Code          string `json:"code"`
+	Scope         string `json:"scope"`
+	Table         string `json:"table"`
+	IndexPosition string `json:"index_position,omitempty"`
+	KeyType       string `json:"key_type,omitempty"`
+	LowerBound    string `json:"lower_bound,omitempty"`
+	UpperBound    string `json:"upper_bound,omitempty"`
+	Limit         uint32 `json:"limit,omitempty"`
+	Reverse       bool   `json:"reverse,omitempty"`
+	ShowPayer     bool   `json:"show_payer,omitempty"`
+	JSON          bool   `json:"json"`
+}
+
+// A single table row.
+// Data is the raw json of the row (or a hex string if the
+// request was made with JSON set to false).
+type TableRow struct {
+	Data  jsoniter.RawMessage
+	Payer Name
+}
+
+// Decode unmarshals the row data into v.
+func (r TableRow) Decode(v interface{}) error {
+	return json.Unmarshal(r.Data, v)
+}
+
+// get_table_rows format
+type TableRows struct {
+	Rows    []TableRow
+	More    bool
+	NextKey string
+}
+
+// TableRowsIterator iterates over all rows matching a request,
+// following "more"/"next_key" to fetch the next page when needed.
+//
+//	it := client.NewTableRowsIterator(req)
+//	for it.Next(ctx) {
+//		var row MyRow
+//		if err := it.Decode(&row); err != nil {
+//			...
+//		}
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type TableRowsIterator struct {
+	client *Client
+	req    TableRowsRequest
+	rows   []TableRow
+	row    TableRow
+	more   bool
+	err    error
+}
+
+// NewTableRowsIterator creates a new iterator starting at req.
+func (c *Client) NewTableRowsIterator(req TableRowsRequest) *TableRowsIterator {
+	return &TableRowsIterator{
+		client: c,
+		req:    req,
+		more:   true,
+	}
+}
+
+// Next advances the iterator to the next row.
+// Returns false when there are no more rows or an error occurred.
+func (it *TableRowsIterator) Next(ctx context.Context) bool {
+	for len(it.rows) < 1 {
+		if !it.more || it.err != nil {
+			return false
+		}
+
+		res, err := it.client.GetTableRows(ctx, it.req)
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		// Stop if the node does not report a next key,
+		// otherwise we would fetch the same page forever.
+		it.more = res.More && len(res.NextKey) > 0
+		if it.req.Reverse {
+			it.req.UpperBound = res.NextKey
+		} else {
+			it.req.LowerBound = res.NextKey
+		}
+		it.rows = res.Rows
+	}
+
+	it.row, it.rows = it.rows[0], it.rows[1:]
+	return true
+}
+
+// Row returns the current row.
+func (it *TableRowsIterator) Row() TableRow {
+	return it.row
+}
+
+// Decode unmarshals the current row data into v.
+func (it *TableRowsIterator) Decode(v interface{}) error {
+	return it.row.Decode(v)
+}
+
+// Err returns the error (if any) that stopped the iteration.
+func (it *TableRowsIterator) Err() error {
+	return it.err
+}
+
+// get_table_by_scope request parameters
+type TableByScopeRequest struct {
+	Code       string `json:"code"`
+	Table      string `json:"table,omitempty"`
+	LowerBound string `json:"lower_bound,omitempty"`
+	UpperBound string `json:"upper_bound,omitempty"`
+	Limit      uint32 `json:"limit,omitempty"`
+	Reverse    bool   `json:"reverse,omitempty"`
+}
+
+// Table scope row
+type TableScope struct {
+	Code  Name   `json:"code"`
+	Scope Name   `json:"scope"`
+	Table Name   `json:"table"`
+	Payer Name   `json:"payer"`
+	Count uint32 `json:"count"`
+}
+
+// get_table_by_scope format
+//
+// More is the scope to continue from or empty if there are no more rows.
+type TableScopes struct {
+	Rows []TableScope `json:"rows"`
+	More string       `json:"more"`
+}
+
+// TableScopeIterator iterates over all scopes matching a request,
+// following "more" to fetch the next page when needed.
+type TableScopeIterator struct {
+	client *Client
+	req    TableByScopeRequest
+	rows   []TableScope
+	row    TableScope
+	more   bool
+	err    error
+}
+
+// NewTableScopeIterator creates a new iterator starting at req.
+func (c *Client) NewTableScopeIterator(req TableByScopeRequest) *TableScopeIterator {
+	return &TableScopeIterator{
+		client: c,
+		req:    req,
+		more:   true,
+	}
+}
+
+// Next advances the iterator to the next scope.
+// Returns false when there are no more scopes or an error occurred
+// (including ctx being canceled).
+func (it *TableScopeIterator) Next(ctx context.Context) bool {
+	for len(it.rows) < 1 {
+		if !it.more || it.err != nil {
+			return false
+		}
+
+		res, err := it.client.GetTableByScope(ctx, it.req)
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.more = len(res.More) > 0
+		if it.req.Reverse {
+			it.req.UpperBound = res.More
+		} else {
+			it.req.LowerBound = res.More
+		}
+		it.rows = res.Rows
+	}
+
+	it.row, it.rows = it.rows[0], it.rows[1:]
+	return true
+}
+
+// Scope returns the current scope.
+func (it *TableScopeIterator) Scope() TableScope {
+	return it.row
+}
+
+// Err returns the error (if any) that stopped the iteration.
+func (it *TableScopeIterator) Err() error {
+	return it.err
+}
@@ -0,0 +1,207 @@
+package leapapi
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testAccountRow struct {
+	Balance Asset `json:"balance"`
+}
+
+func TestGetTableRows(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v1/chain/get_table_rows", req.URL.String())
+
+		body, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+            "code": "eosio.token",
+            "scope": "someaccount1",
+            "table": "accounts",
+            "limit": 10,
+            "show_payer": true,
+            "json": true
+        }`, string(body))
+
+		payload := `{
+            "rows": [{"data": {"balance": "1.0000 EOS"}, "payer": "someaccount1"}],
+            "more": false,
+            "next_key": ""
+        }`
+		_, _ = res.Write([]byte(payload))
+	}))
+
+	client := New(srv.URL)
+
+	rows, err := client.GetTableRows(context.Background(), TableRowsRequest{
+		Code:      "eosio.token",
+		Scope:     "someaccount1",
+		Table:     "accounts",
+		Limit:     10,
+		ShowPayer: true,
+		JSON:      true,
+	})
+	require.NoError(t, err)
+	assert.False(t, rows.More)
+	require.Len(t, rows.Rows, 1)
+	assert.Equal(t, Name("someaccount1"), rows.Rows[0].Payer)
+
+	var row testAccountRow
+	require.NoError(t, rows.Rows[0].Decode(&row))
+	assert.Equal(t, "1.0000 EOS", row.Balance.String())
+}
+
+func TestTableRowsIterator(t *testing.T) {
+	pages := map[string]string{
+		"":  `{"rows": [{"balance": "1.0000 EOS"}, {"balance": "2.0000 EOS"}], "more": true, "next_key": "b"}`,
+		"b": `{"rows": [], "more": true, "next_key": "c"}`,
+		"c": `{"rows": [{"balance": "3.0000 EOS"}], "more": false, "next_key": ""}`,
+	}
+
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		var params TableRowsRequest
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&params))
+
+		calls++
+		_, _ = res.Write([]byte(pages[params.LowerBound]))
+	}))
+
+	client := New(srv.URL)
+	it := client.NewTableRowsIterator(TableRowsRequest{Code: "eosio.token", Scope: "eosio.token", Table: "accounts", JSON: true})
+
+	balances := []string{}
+	for it.Next(context.Background()) {
+		var row testAccountRow
+		require.NoError(t, it.Decode(&row))
+		balances = append(balances, row.Balance.String())
+	}
+
+	require.NoError(t, it.Err())
+	assert.Equal(t, []string{"1.0000 EOS", "2.0000 EOS", "3.0000 EOS"}, balances)
+	assert.Equal(t, 3, calls)
+	assert.False(t, it.Next(context.Background()))
+}
+
+func TestTableRowsIteratorReverse(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		var params TableRowsRequest
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&params))
+		assert.Empty(t, params.LowerBound)
+
+		if params.UpperBound == "" {
+			_, _ = res.Write([]byte(`{"rows": [3, 2], "more": true, "next_key": "1"}`))
+		} else {
+			assert.Equal(t, "1", params.UpperBound)
+			_, _ = res.Write([]byte(`{"rows": [1], "more": false, "next_key": ""}`))
+		}
+	}))
+
+	client := New(srv.URL)
+	it := client.NewTableRowsIterator(TableRowsRequest{Reverse: true, JSON: true})
+
+	values := []int{}
+	for it.Next(context.Background()) {
+		var v int
+		require.NoError(t, it.Decode(&v))
+		values = append(values, v)
+	}
+
+	require.NoError(t, it.Err())
+	assert.Equal(t, []int{3, 2, 1}, values)
+}
+
+func TestTableRowsIteratorError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.WriteHeader(500)
+	}))
+
+	client := New(srv.URL)
+	it := client.NewTableRowsIterator(TableRowsRequest{})
+
+	assert.False(t, it.Next(context.Background()))
+	assert.EqualError(t, it.Err(), "server returned HTTP 500 Internal Server Error")
+}
+
+func TestGetTableByScope(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v1/chain/get_table_by_scope", req.URL.String())
+
+		body, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"code": "eosio.token", "table": "accounts", "limit": 2}`, string(body))
+
+		payload := `{
+            "rows": [
+                {"code": "eosio.token", "scope": "someaccount1", "table": "accounts", "payer": "someaccount1", "count": 1},
+                {"code": "eosio.token", "scope": "someaccount2", "table": "accounts", "payer": "eosio", "count": 2}
+            ],
+            "more": "someaccount3"
+        }`
+		_, _ = res.Write([]byte(payload))
+	}))
+
+	client := New(srv.URL)
+
+	scopes, err := client.GetTableByScope(context.Background(), TableByScopeRequest{
+		Code:  "eosio.token",
+		Table: "accounts",
+		Limit: 2,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "someaccount3", scopes.More)
+	assert.Equal(t, []TableScope{
+		{Code: "eosio.token", Scope: "someaccount1", Table: "accounts", Payer: "someaccount1", Count: 1},
+		{Code: "eosio.token", Scope: "someaccount2", Table: "accounts", Payer: "eosio", Count: 2},
+	}, scopes.Rows)
+}
+
+func TestTableScopeIterator(t *testing.T) {
+	pages := map[string]string{
+		"":  `{"rows": [{"scope": "a"}, {"scope": "b"}], "more": "c"}`,
+		"c": `{"rows": [{"scope": "c"}], "more": ""}`,
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		var params TableByScopeRequest
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&params))
+		_, _ = res.Write([]byte(pages[params.LowerBound]))
+	}))
+
+	client := New(srv.URL)
+	it := client.NewTableScopeIterator(TableByScopeRequest{Code: "eosio.token"})
+
+	scopes := []Name{}
+	for it.Next(context.Background()) {
+		scopes = append(scopes, it.Scope().Scope)
+	}
+
+	require.NoError(t, it.Err())
+	assert.Equal(t, []Name{"a", "b", "c"}, scopes)
+}
+
+func TestTableScopeIteratorContextCancel(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		calls++
+		_, _ = res.Write([]byte(`{"rows": [{"scope": "a"}], "more": "a"}`))
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	client := New(srv.URL)
+	it := client.NewTableScopeIterator(TableByScopeRequest{Code: "eosio.token"})
+
+	require.True(t, it.Next(ctx))
+	cancel()
+
+	assert.False(t, it.Next(ctx))
+	assert.Error(t, it.Err())
+	assert.Equal(t, 1, calls)
+}
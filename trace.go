@@ -0,0 +1,261 @@
+package leapapi
+
+import (
+	"fmt"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Exception stack context.
+type ExceptionContext struct {
+	Level      string    `json:"level"`
+	File       string    `json:"file"`
+	Line       int64     `json:"line"`
+	Method     string    `json:"method"`
+	Hostname   string    `json:"hostname"`
+	ThreadName string    `json:"thread_name"`
+	Timestamp  time.Time `json:"timestamp" time_format:"2006-01-02T15:04:05.000"`
+}
+
+// Exception stack entry.
+type ExceptionStack struct {
+	Context ExceptionContext       `json:"context"`
+	Format  string                 `json:"format"`
+	Data    map[string]interface{} `json:"data"`
+}
+
+// Exception format (the "except" field of traces)
+type TraceException struct {
+	Code    int64            `json:"code"`
+	Name    string           `json:"name"`
+	Message string           `json:"message"`
+	Stack   []ExceptionStack `json:"stack"`
+}
+
+// APIError converts the exception into an APIError (same format
+// as when nodeos reports the exception as a http error).
+func (e TraceException) APIError() APIError {
+	details := make([]APIErrorDetail, len(e.Stack))
+	for i, s := range e.Stack {
+		details[i] = APIErrorDetail{
+			Message: s.Format,
+			File:    s.Context.File,
+			Line:    s.Context.Line,
+			Method:  s.Context.Method,
+		}
+	}
+
+	return APIError{
+		Code:    500,
+		Message: "Internal Service Error",
+		Err: APIErrorInner{
+			Code:    e.Code,
+			Name:    e.Name,
+			What:    e.Message,
+			Details: details,
+		},
+	}
+}
+
+// Account and sequence pair. Encoded as a [account, sequence]
+// pair in json.
+type AuthSequence struct {
+	Account  Name
+	Sequence Uint64
+}
+
+func (a AuthSequence) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]interface{}{a.Account, a.Sequence})
+}
+
+func (a *AuthSequence) UnmarshalJSON(b []byte) error {
+	var r []jsoniter.RawMessage
+
+	err := json.Unmarshal(b, &r)
+	if err != nil {
+		return err
+	}
+
+	if len(r) != 2 {
+		return fmt.Errorf("auth sequence: expected 2 elements, got %d", len(r))
+	}
+
+	if err = json.Unmarshal(r[0], &a.Account); err != nil {
+		return err
+	}
+	return json.Unmarshal(r[1], &a.Sequence)
+}
+
+// Action receipt format
+type ActionReceipt struct {
+	Receiver       Name           `json:"receiver"`
+	ActDigest      string         `json:"act_digest"`
+	GlobalSequence Uint64         `json:"global_sequence"`
+	RecvSequence   Uint64         `json:"recv_sequence"`
+	AuthSequence   []AuthSequence `json:"auth_sequence"`
+	CodeSequence   uint32         `json:"code_sequence"`
+	ABISequence    uint32         `json:"abi_sequence"`
+}
+
+// Account ram delta
+type AccountDelta struct {
+	Account Name  `json:"account"`
+	Delta   int64 `json:"delta"`
+}
+
+// Action trace format
+type ActionTrace struct {
+	ActionOrdinal                          uint32          `json:"action_ordinal"`
+	CreatorActionOrdinal                   uint32          `json:"creator_action_ordinal"`
+	ClosestUnnotifiedAncestorActionOrdinal uint32          `json:"closest_unnotified_ancestor_action_ordinal"`
+	Receipt                                *ActionReceipt  `json:"receipt"`
+	Receiver                               Name            `json:"receiver"`
+	Act                                    Action          `json:"act"`
+	ContextFree                            bool            `json:"context_free"`
+	Elapsed                                int64           `json:"elapsed"`
+	Console                                string          `json:"console"`
+	TrxID                                  string          `json:"trx_id"`
+	BlockNum                               uint32          `json:"block_num"`
+	BlockTime                              time.Time       `json:"block_time" time_format:"2006-01-02T15:04:05.000"`
+	ProducerBlockID                        string          `json:"producer_block_id"`
+	AccountRAMDeltas                       []AccountDelta  `json:"account_ram_deltas"`
+	Except                                 *TraceException `json:"except"`
+	ErrorCode                              *Uint64         `json:"error_code"`
+	ReturnValueHexData                     string          `json:"return_value_hex_data,omitempty"`
+}
+
+// Transaction receipt header (receipt without the transaction)
+type TransactionReceiptHeader struct {
+	Status        string `json:"status"`
+	CPUUsageUS    uint32 `json:"cpu_usage_us"`
+	NetUsageWords uint32 `json:"net_usage_words"`
+}
+
+// Transaction trace format
+type TransactionTrace struct {
+	ID              string                    `json:"id"`
+	BlockNum        uint32                    `json:"block_num"`
+	BlockTime       time.Time                 `json:"block_time" time_format:"2006-01-02T15:04:05.000"`
+	ProducerBlockID string                    `json:"producer_block_id"`
+	Receipt         *TransactionReceiptHeader `json:"receipt"`
+	Elapsed         int64                     `json:"elapsed"`
+	NetUsage        uint64                    `json:"net_usage"`
+	Scheduled       bool                      `json:"scheduled"`
+	ActionTraces    []ActionTrace             `json:"action_traces"`
+	AccountRAMDelta *AccountDelta             `json:"account_ram_delta"`
+	Except          *TraceException           `json:"except"`
+	ErrorCode       *Uint64                   `json:"error_code"`
+}
+
+// Console returns the console output of all actions.
+func (t TransactionTrace) Console() string {
+	out := ""
+	for _, a := range t.ActionTraces {
+		out += a.Console
+	}
+	return out
+}
+
+// Estimated resource usage of an action.
+type ActionUsage struct {
+	Account       Name
+	Name          Name
+	CPUUsageUS    uint32
+	NetUsageWords uint32
+}
+
+// Size of an action in binary format.
+//
+// Nodeos sends data as a hex string for actions without an abi, that is
+// decoded here. If the data is only available in decoded form (no hex_data)
+// the size without data is returned.
+func (a Action) packedSize() int {
+	if s, ok := a.Data.(string); ok && len(a.HexData) < 1 {
+		a.HexData = s
+	}
+
+	e := &encoder{}
+	if err := a.pack(e); err != nil {
+		a.Data, a.HexData = nil, ""
+		e = &encoder{}
+		if err := a.pack(e); err != nil {
+			return 0
+		}
+	}
+	return len(e.Bytes())
+}
+
+// Split total proportionally to weights. The rounding remainder is added to the last element.
+func splitUsage(total uint32, weights []uint64) []uint32 {
+	var sum uint64
+	for _, w := range weights {
+		sum += w
+	}
+
+	out := make([]uint32, len(weights))
+	var used uint32
+	for i, w := range weights {
+		if sum > 0 {
+			out[i] = uint32(uint64(total) * w / sum)
+		} else {
+			out[i] = total / uint32(len(weights))
+		}
+		used += out[i]
+	}
+
+	if len(out) > 0 {
+		out[len(out)-1] += total - used
+	}
+	return out
+}
+
+// ActionUsage estimates the cpu and net usage of each (top level) action
+// in the transaction.
+//
+// Nodeos only reports usage for the whole transaction, so the cpu usage
+// is split by the elapsed time of each action (including inline actions
+// and notifications created by it) and net usage is split by the size of
+// each action.
+func (t TransactionTrace) ActionUsage() []ActionUsage {
+	// Map action ordinal to the index of the top level action.
+	roots := map[uint32]int{}
+	usage := []ActionUsage{}
+	for _, at := range t.ActionTraces {
+		if at.CreatorActionOrdinal == 0 && !at.ContextFree {
+			roots[at.ActionOrdinal] = len(usage)
+			usage = append(usage, ActionUsage{Account: at.Act.Account, Name: at.Act.Name})
+		}
+	}
+
+	if t.Receipt == nil || len(usage) < 1 {
+		return usage
+	}
+
+	elapsed := make([]uint64, len(usage))
+	size := make([]uint64, len(usage))
+	for _, at := range t.ActionTraces {
+		// Traces are ordered so that the creator is always seen first.
+		i, ok := roots[at.ActionOrdinal]
+		if !ok {
+			if i, ok = roots[at.CreatorActionOrdinal]; !ok {
+				continue
+			}
+			roots[at.ActionOrdinal] = i
+		} else {
+			size[i] = uint64(at.Act.packedSize())
+		}
+
+		if at.Elapsed > 0 {
+			elapsed[i] += uint64(at.Elapsed)
+		}
+	}
+
+	cpu := splitUsage(t.Receipt.CPUUsageUS, elapsed)
+	net := splitUsage(t.Receipt.NetUsageWords, size)
+	for i := range usage {
+		usage[i].CPUUsageUS = cpu[i]
+		usage[i].NetUsageWords = net[i]
+	}
+	return usage
+}
@@ -0,0 +1,412 @@
+package leapapi
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTransactionTrace = `{
+    "id": "668b2b96ce802ca9599cc56042e5b5f4bb93763c30bc0693880f2fb85b573ebb",
+    "block_num": 300000001,
+    "block_time": "2023-03-01T10:00:00.500",
+    "producer_block_id": null,
+    "receipt": {"status": "executed", "cpu_usage_us": 172, "net_usage_words": 16},
+    "elapsed": 172,
+    "net_usage": 128,
+    "scheduled": false,
+    "action_traces": [
+        {
+            "action_ordinal": 1,
+            "creator_action_ordinal": 0,
+            "closest_unnotified_ancestor_action_ordinal": 0,
+            "receipt": {
+                "receiver": "eosio.token",
+                "act_digest": "c7d4a2e0fb9c2a6fd7a4bc33c9e0b3e8a4f1b0d6c2e8b6f0a7d4c3b2a1908070",
+                "global_sequence": "304500000001",
+                "recv_sequence": 7,
+                "auth_sequence": [["alice", "18446744073709551615"]],
+                "code_sequence": 3,
+                "abi_sequence": 2
+            },
+            "receiver": "eosio.token",
+            "act": {
+                "account": "eosio.token",
+                "name": "transfer",
+                "authorization": [{"actor": "alice", "permission": "active"}],
+                "data": {"from": "alice", "to": "bob", "quantity": "1.0000 EOS", "memo": "hi"},
+                "hex_data": "0000000000855c340000000000000e3d102700000000000004454f5300000000026869"
+            },
+            "context_free": false,
+            "elapsed": 80,
+            "console": "hello ",
+            "trx_id": "668b2b96ce802ca9599cc56042e5b5f4bb93763c30bc0693880f2fb85b573ebb",
+            "block_num": 300000001,
+            "block_time": "2023-03-01T10:00:00.500",
+            "producer_block_id": null,
+            "account_ram_deltas": [{"account": "alice", "delta": -12}],
+            "except": null,
+            "error_code": null,
+            "return_value_hex_data": ""
+        },
+        {
+            "action_ordinal": 2,
+            "creator_action_ordinal": 1,
+            "receiver": "alice",
+            "act": {"account": "eosio.token", "name": "transfer", "authorization": [], "data": {}},
+            "console": "world",
+            "account_ram_deltas": []
+        }
+    ],
+    "account_ram_delta": null,
+    "except": null,
+    "error_code": null
+}`
+
+const testTraceException = `{
+    "code": 3080004,
+    "name": "tx_cpu_usage_exceeded",
+    "message": "Transaction exceeded the current CPU usage limit imposed on the transaction",
+    "stack": [
+        {
+            "context": {
+                "level": "error",
+                "file": "transaction_context.cpp",
+                "line": 556,
+                "method": "validate_account_cpu_usage",
+                "hostname": "",
+                "thread_name": "nodeos",
+                "timestamp": "2023-03-01T10:00:00.123"
+            },
+            "format": "billed CPU time (${billed} us) is greater than the maximum billable CPU time for the transaction (${billable} us)",
+            "data": {"billed": 1200, "billable": 1000}
+        }
+    ]
+}`
+
+func TestTransactionTrace_JsonDecode(t *testing.T) {
+	var trace TransactionTrace
+
+	err := json.Unmarshal([]byte(testTransactionTrace), &trace)
+	require.NoError(t, err)
+
+	assert.Equal(t, "668b2b96ce802ca9599cc56042e5b5f4bb93763c30bc0693880f2fb85b573ebb", trace.ID)
+	assert.Equal(t, uint32(300000001), trace.BlockNum)
+	assert.Equal(t, time.Date(2023, 3, 1, 10, 0, 0, 500000000, time.UTC), trace.BlockTime)
+	assert.Equal(t, &TransactionReceiptHeader{Status: "executed", CPUUsageUS: 172, NetUsageWords: 16}, trace.Receipt)
+	assert.Equal(t, int64(172), trace.Elapsed)
+	assert.Equal(t, uint64(128), trace.NetUsage)
+	assert.Nil(t, trace.Except)
+	assert.Nil(t, trace.ErrorCode)
+	assert.Equal(t, "hello world", trace.Console())
+
+	require.Len(t, trace.ActionTraces, 2)
+	at := trace.ActionTraces[0]
+	assert.Equal(t, Name("eosio.token"), at.Receiver)
+	assert.Equal(t, Name("transfer"), at.Act.Name)
+	assert.Equal(t, "0000000000855c340000000000000e3d102700000000000004454f5300000000026869", at.Act.HexData)
+	assert.Equal(t, []AccountDelta{{Account: "alice", Delta: -12}}, at.AccountRAMDeltas)
+
+	require.NotNil(t, at.Receipt)
+	assert.Equal(t, Uint64(304500000001), at.Receipt.GlobalSequence)
+	assert.Equal(t, Uint64(7), at.Receipt.RecvSequence)
+	assert.Equal(t, []AuthSequence{{Account: "alice", Sequence: 18446744073709551615}}, at.Receipt.AuthSequence)
+
+	assert.Nil(t, trace.ActionTraces[1].Receipt)
+	assert.Equal(t, uint32(1), trace.ActionTraces[1].CreatorActionOrdinal)
+}
+
+func TestTraceException_APIError(t *testing.T) {
+	var except TraceException
+
+	err := json.Unmarshal([]byte(testTraceException), &except)
+	require.NoError(t, err)
+
+	assert.Equal(t, APIError{
+		Code:    500,
+		Message: "Internal Service Error",
+		Err: APIErrorInner{
+			Code: 3080004,
+			Name: "tx_cpu_usage_exceeded",
+			What: "Transaction exceeded the current CPU usage limit imposed on the transaction",
+			Details: []APIErrorDetail{{
+				Message: "billed CPU time (${billed} us) is greater than the maximum billable CPU time for the transaction (${billable} us)",
+				File:    "transaction_context.cpp",
+				Line:    556,
+				Method:  "validate_account_cpu_usage",
+			}},
+		},
+	}, except.APIError())
+}
+
+var testPackedTransaction = PackedTransaction{
+	Signatures:  []string{"SIG_K1_KfQ57wLFFsSgLbcEhpRfGGoLb1GQ9AmXUP4ViY4PTpUbNPPe2q5dWG1rm8UV6ZeVuY5gNnBQKoHqXDwufAPwwTSnWMFyD7"},
+	Compression: "none",
+	PackedTrx:   testPackedTrx,
+}
+
+const testPackedTransactionJSON = `{
+    "signatures": ["SIG_K1_KfQ57wLFFsSgLbcEhpRfGGoLb1GQ9AmXUP4ViY4PTpUbNPPe2q5dWG1rm8UV6ZeVuY5gNnBQKoHqXDwufAPwwTSnWMFyD7"],
+    "compression": "none",
+    "packed_context_free_data": "",
+    "packed_trx": "` + testPackedTrx + `"
+}`
+
+func TestPushTransaction(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v1/chain/push_transaction", req.URL.String())
+
+		body, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, testPackedTransactionJSON, string(body))
+
+		_, _ = res.Write([]byte(`{"transaction_id": "668b2b96ce802ca9599cc56042e5b5f4bb93763c30bc0693880f2fb85b573ebb", "processed": ` + testTransactionTrace + `}`))
+	}))
+
+	client := New(srv.URL)
+	trace, err := client.PushTransaction(context.Background(), testPackedTransaction)
+
+	require.NoError(t, err)
+	assert.Equal(t, "668b2b96ce802ca9599cc56042e5b5f4bb93763c30bc0693880f2fb85b573ebb", trace.ID)
+	assert.Len(t, trace.ActionTraces, 2)
+}
+
+func TestSendTransaction(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v1/chain/send_transaction", req.URL.String())
+
+		body, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, testPackedTransactionJSON, string(body))
+
+		_, _ = res.Write([]byte(`{"transaction_id": "668b2b96ce802ca9599cc56042e5b5f4bb93763c30bc0693880f2fb85b573ebb", "processed": ` + testTransactionTrace + `}`))
+	}))
+
+	client := New(srv.URL)
+	trace, err := client.SendTransaction(context.Background(), testPackedTransaction)
+
+	require.NoError(t, err)
+	assert.Equal(t, "executed", trace.Receipt.Status)
+}
+
+func TestSendTransactionError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		payload := `{
+            "code": 500,
+            "message": "Internal Service Error",
+            "error": {
+                "code": 3040005,
+                "name": "expired_tx_exception",
+                "what": "Expired Transaction",
+                "details": [
+                    {
+                        "message": "expired transaction 668b2b96ce802ca9599cc56042e5b5f4bb93763c30bc0693880f2fb85b573ebb",
+                        "file": "producer_plugin.cpp",
+                        "line_number": 1920,
+                        "method": "process_incoming_transaction_async"
+                    }
+                ]
+            }
+        }`
+		res.WriteHeader(500)
+		_, _ = res.Write([]byte(payload))
+	}))
+
+	client := New(srv.URL)
+	_, err := client.SendTransaction(context.Background(), testPackedTransaction)
+	require.EqualError(t, err, "500 Internal Service Error")
+
+	api_err, ok := err.(APIError)
+	require.True(t, ok)
+	assert.Equal(t, "expired_tx_exception", api_err.Err.Name)
+	assert.Len(t, api_err.Err.Details, 1)
+}
+
+func TestSendTransaction2(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v1/chain/send_transaction2", req.URL.String())
+
+		body, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+            "return_failure_trace": false,
+            "retry_trx": true,
+            "retry_trx_num_blocks": 10,
+            "transaction": `+testPackedTransactionJSON+`
+        }`, string(body))
+
+		_, _ = res.Write([]byte(`{"transaction_id": "668b2b96ce802ca9599cc56042e5b5f4bb93763c30bc0693880f2fb85b573ebb", "processed": ` + testTransactionTrace + `}`))
+	}))
+
+	client := New(srv.URL)
+	trace, err := client.SendTransaction2(context.Background(), testPackedTransaction, SendTransaction2Options{
+		RetryTrx:          true,
+		RetryTrxNumBlocks: 10,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, uint32(300000001), trace.BlockNum)
+}
+
+func TestSendTransaction2FailureTrace(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+            "return_failure_trace": true,
+            "retry_trx": false,
+            "transaction": `+testPackedTransactionJSON+`
+        }`, string(body))
+
+		payload := `{
+            "transaction_id": "668b2b96ce802ca9599cc56042e5b5f4bb93763c30bc0693880f2fb85b573ebb",
+            "processed": {
+                "id": "668b2b96ce802ca9599cc56042e5b5f4bb93763c30bc0693880f2fb85b573ebb",
+                "receipt": null,
+                "elapsed": 1200,
+                "action_traces": [],
+                "except": ` + testTraceException + `,
+                "error_code": "10000000000000000000"
+            }
+        }`
+		_, _ = res.Write([]byte(payload))
+	}))
+
+	client := New(srv.URL)
+	trace, err := client.SendTransaction2(context.Background(), testPackedTransaction, SendTransaction2Options{
+		ReturnFailureTrace: true,
+	})
+	require.EqualError(t, err, "500 Internal Service Error")
+
+	api_err, ok := err.(APIError)
+	require.True(t, ok)
+	assert.Equal(t, "tx_cpu_usage_exceeded", api_err.Err.Name)
+
+	require.NotNil(t, trace.Except)
+	assert.Equal(t, int64(3080004), trace.Except.Code)
+	assert.Nil(t, trace.Receipt)
+	require.NotNil(t, trace.ErrorCode)
+	assert.Equal(t, Uint64(10000000000000000000), *trace.ErrorCode)
+}
+
+func TestComputeTransaction(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v1/chain/compute_transaction", req.URL.String())
+
+		body, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"transaction": {
+            "signatures": [],
+            "compression": "none",
+            "packed_context_free_data": "",
+            "packed_trx": "`+testPackedTrx+`"
+        }}`, string(body))
+
+		_, _ = res.Write([]byte(`{"transaction_id": "668b2b96ce802ca9599cc56042e5b5f4bb93763c30bc0693880f2fb85b573ebb", "processed": ` + testTransactionTrace + `}`))
+	}))
+
+	client := New(srv.URL)
+	trace, err := client.ComputeTransaction(context.Background(), PackedTransaction{PackedTrx: testPackedTrx})
+
+	require.NoError(t, err)
+	assert.Equal(t, uint32(172), trace.Receipt.CPUUsageUS)
+}
+
+func TestComputeTransactionFailure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		payload := `{
+            "transaction_id": "668b2b96ce802ca9599cc56042e5b5f4bb93763c30bc0693880f2fb85b573ebb",
+            "processed": {"id": "668b2b96ce802ca9599cc56042e5b5f4bb93763c30bc0693880f2fb85b573ebb", "except": ` + testTraceException + `}
+        }`
+		_, _ = res.Write([]byte(payload))
+	}))
+
+	client := New(srv.URL)
+	trace, err := client.ComputeTransaction(context.Background(), PackedTransaction{PackedTrx: testPackedTrx})
+
+	require.EqualError(t, err, "500 Internal Service Error")
+	assert.Equal(t, "tx_cpu_usage_exceeded", err.(APIError).Err.Name)
+	require.NotNil(t, trace.Except)
+}
+
+func TestTransactionTrace_ActionUsage(t *testing.T) {
+	transfer := func(ordinal, creator uint32, elapsed int64) ActionTrace {
+		return ActionTrace{
+			ActionOrdinal:        ordinal,
+			CreatorActionOrdinal: creator,
+			Elapsed:              elapsed,
+			Act: Action{
+				Account:       "eosio.token",
+				Name:          "transfer",
+				Authorization: []PermissionLevel{{Actor: "alice", Permission: "active"}},
+				HexData:       "0000000000855c340000000000000e3d102700000000000004454f5300000000026869",
+			},
+		}
+	}
+
+	buy := transfer(2, 0, 150)
+	buy.Act.Account = "eosio"
+	buy.Act.Name = "buyram"
+	buy.Act.HexData = "0000000000855c34"
+
+	trace := TransactionTrace{
+		Receipt: &TransactionReceiptHeader{CPUUsageUS: 500, NetUsageWords: 31},
+		ActionTraces: []ActionTrace{
+			transfer(1, 0, 50),
+			buy,
+			// notification of action 1 and inline action of action 2
+			transfer(3, 1, 50),
+			transfer(4, 2, 150),
+			// inline of inline (action 4)
+			transfer(5, 4, 100),
+		},
+	}
+
+	// cpu: 100 / 500 and 400 / 500 us of 500
+	// net: 69 / 111 and 42 / 111 bytes of 31 words (remainder to last)
+	assert.Equal(t, []ActionUsage{
+		{Account: "eosio.token", Name: "transfer", CPUUsageUS: 100, NetUsageWords: 19},
+		{Account: "eosio", Name: "buyram", CPUUsageUS: 400, NetUsageWords: 12},
+	}, trace.ActionUsage())
+
+	assert.Equal(t, []ActionUsage{}, TransactionTrace{}.ActionUsage())
+}
+
+func TestTransactionTrace_ActionUsageHexData(t *testing.T) {
+	action := func(ordinal uint32, data interface{}) ActionTrace {
+		return ActionTrace{
+			ActionOrdinal: ordinal,
+			Elapsed:       100,
+			Act: Action{
+				Account:       "eosio.token",
+				Name:          "transfer",
+				Authorization: []PermissionLevel{{Actor: "alice", Permission: "active"}},
+				Data:          data,
+			},
+		}
+	}
+
+	trace := TransactionTrace{
+		Receipt: &TransactionReceiptHeader{CPUUsageUS: 300, NetUsageWords: 20},
+		ActionTraces: []ActionTrace{
+			// No abi, nodeos sends data as hex.
+			action(1, "0000000000855c340000000000000e3d102700000000000004454f5300000000026869"),
+			action(2, "0000000000855c34"),
+			// Decoded data without hex_data, only the action header is counted.
+			action(3, map[string]interface{}{"from": "alice"}),
+		},
+	}
+
+	// net: 69 / 145, 42 / 145 and 34 / 145 bytes of 20 words (remainder to last)
+	assert.Equal(t, []ActionUsage{
+		{Account: "eosio.token", Name: "transfer", CPUUsageUS: 100, NetUsageWords: 9},
+		{Account: "eosio.token", Name: "transfer", CPUUsageUS: 100, NetUsageWords: 5},
+		{Account: "eosio.token", Name: "transfer", CPUUsageUS: 100, NetUsageWords: 6},
+	}, trace.ActionUsage())
+}
@@ -0,0 +1,299 @@
+package leapapi
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// Permission level (actor@permission)
+type PermissionLevel struct {
+	Actor      Name `json:"actor"`
+	Permission Name `json:"permission"`
+}
+
+// Action format
+type Action struct {
+	Account       Name              `json:"account"`
+	Name          Name              `json:"name"`
+	Authorization []PermissionLevel `json:"authorization"`
+	Data          interface{}       `json:"data"`
+	HexData       string            `json:"hex_data,omitempty"`
+}
+
+// SetData encodes v using abi and sets both Data and HexData.
+func (a *Action) SetData(abi *ABI, v interface{}) error {
+	data, err := abi.EncodeAction(string(a.Name), v)
+	if err != nil {
+		return err
+	}
+
+	a.Data = v
+	a.HexData = hex.EncodeToString(data)
+	return nil
+}
+
+// Binary action data. HexData is used if set, otherwise
+// Data must already be binary ([]byte or HexBytes).
+func (a Action) binaryData() ([]byte, error) {
+	if len(a.HexData) > 0 {
+		return hex.DecodeString(a.HexData)
+	}
+
+	switch d := a.Data.(type) {
+	case nil:
+		return []byte{}, nil
+	case []byte:
+		return d, nil
+	case HexBytes:
+		return d, nil
+	}
+	return nil, fmt.Errorf("action %s::%s: data is not packed (use Action.SetData)", a.Account, a.Name)
+}
+
+func (a Action) pack(e *encoder) error {
+	for _, n := range []Name{a.Account, a.Name} {
+		v, err := n.Uint64()
+		if err != nil {
+			return err
+		}
+		e.writeUint64(v)
+	}
+
+	e.writeVarUint32(uint32(len(a.Authorization)))
+	for _, auth := range a.Authorization {
+		for _, n := range []Name{auth.Actor, auth.Permission} {
+			v, err := n.Uint64()
+			if err != nil {
+				return err
+			}
+			e.writeUint64(v)
+		}
+	}
+
+	data, err := a.binaryData()
+	if err == nil {
+		e.writeByteArray(data)
+	}
+	return err
+}
+
+// Transaction format
+type Transaction struct {
+	Expiration         time.Time   `json:"expiration"`
+	RefBlockNum        uint16      `json:"ref_block_num"`
+	RefBlockPrefix     uint32      `json:"ref_block_prefix"`
+	MaxNetUsageWords   uint32      `json:"max_net_usage_words"`
+	MaxCPUUsageMS      uint8       `json:"max_cpu_usage_ms"`
+	DelaySec           uint32      `json:"delay_sec"`
+	ContextFreeActions []Action    `json:"context_free_actions"`
+	Actions            []Action    `json:"actions"`
+	Extensions         []Extension `json:"transaction_extensions"`
+}
+
+// nodeos does not accept null for vector fields, so nil slices
+// are encoded as empty arrays.
+func (tx Transaction) MarshalJSON() ([]byte, error) {
+	type transaction Transaction
+
+	if tx.ContextFreeActions == nil {
+		tx.ContextFreeActions = []Action{}
+	}
+
+	if tx.Actions == nil {
+		tx.Actions = []Action{}
+	}
+
+	if tx.Extensions == nil {
+		tx.Extensions = []Extension{}
+	}
+	return json.Marshal(transaction(tx))
+}
+
+// SetReferenceBlock sets the TaPoS fields (ref_block_num and
+// ref_block_prefix) from a block id.
+func (tx *Transaction) SetReferenceBlock(id string) error {
+	b, err := hex.DecodeString(id)
+	if err != nil {
+		return err
+	}
+
+	if len(b) != 32 {
+		return fmt.Errorf("invalid block id %q", id)
+	}
+
+	// Block number is stored big endian in the first 4 bytes.
+	tx.RefBlockNum = uint16(binary.BigEndian.Uint32(b[:4]))
+	tx.RefBlockPrefix = binary.LittleEndian.Uint32(b[8:12])
+	return nil
+}
+
+// SetTaPoS references the last irreversible block (or head block if
+// not available) from info and sets the expiration to expireIn after
+// the head block time.
+func (tx *Transaction) SetTaPoS(info Info, expireIn time.Duration) error {
+	id := info.LastIrreversableBlockID
+	if len(id) < 1 {
+		id = info.HeadBlockID
+	}
+
+	if err := tx.SetReferenceBlock(id); err != nil {
+		return err
+	}
+
+	tx.Expiration = info.HeadBlockTime.Add(expireIn).UTC().Truncate(time.Second)
+	return nil
+}
+
+// Pack returns the transaction in binary format.
+func (tx Transaction) Pack() ([]byte, error) {
+	e := &encoder{}
+	e.writeUint32(uint32(tx.Expiration.Unix()))
+	e.writeUint16(tx.RefBlockNum)
+	e.writeUint32(tx.RefBlockPrefix)
+	e.writeVarUint32(tx.MaxNetUsageWords)
+	e.writeUint8(tx.MaxCPUUsageMS)
+	e.writeVarUint32(tx.DelaySec)
+
+	for _, actions := range [][]Action{tx.ContextFreeActions, tx.Actions} {
+		e.writeVarUint32(uint32(len(actions)))
+		for _, a := range actions {
+			if err := a.pack(e); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	e.writeVarUint32(uint32(len(tx.Extensions)))
+	for _, ext := range tx.Extensions {
+		data, err := hex.DecodeString(ext.Data)
+		if err != nil {
+			return nil, err
+		}
+		e.writeUint16(ext.Type)
+		e.writeByteArray(data)
+	}
+	return e.Bytes(), nil
+}
+
+// ID returns the transaction id (sha256 of the packed transaction).
+func (tx Transaction) ID() (string, error) {
+	packed, err := tx.Pack()
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.Sum256(packed)
+	return hex.EncodeToString(h[:]), nil
+}
+
+// SigDigest returns the digest that is signed for a chain.
+// (sha256 of chain id, packed transaction and context free data hash)
+func (tx Transaction) SigDigest(chainID string) ([]byte, error) {
+	chain, err := hex.DecodeString(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(chain) != 32 {
+		return nil, fmt.Errorf("invalid chain id %q", chainID)
+	}
+
+	packed, err := tx.Pack()
+	if err != nil {
+		return nil, err
+	}
+
+	h := sha256.New()
+	_, _ = h.Write(chain)
+	_, _ = h.Write(packed)
+	// No context free data (all zero hash)
+	_, _ = h.Write(make([]byte, 32))
+	return h.Sum(nil), nil
+}
+
+// Sign signs the transaction with keys for a chain.
+func (tx Transaction) Sign(chainID string, keys ...*PrivateKey) ([]string, error) {
+	digest, err := tx.SigDigest(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	signatures := make([]string, len(keys))
+	for i, key := range keys {
+		sig, err := key.Sign(digest)
+		if err != nil {
+			return nil, err
+		}
+		signatures[i] = sig.String()
+	}
+	return signatures, nil
+}
+
+// Packed transaction format
+type PackedTransaction struct {
+	ID                    string      `json:"id,omitempty"`
+	Signatures            []string    `json:"signatures"`
+	Compression           string      `json:"compression"`
+	PackedContextFreeData string      `json:"packed_context_free_data"`
+	ContextFreeData       []string    `json:"context_free_data,omitempty"`
+	PackedTrx             string      `json:"packed_trx"`
+	Transaction           Transaction `json:"transaction"`
+}
+
+// NewPackedTransaction packs a transaction with signatures (uncompressed).
+func NewPackedTransaction(tx Transaction, signatures []string) (PackedTransaction, error) {
+	packed, err := tx.Pack()
+	if err != nil {
+		return PackedTransaction{}, err
+	}
+
+	id := sha256.Sum256(packed)
+	return PackedTransaction{
+		ID:          hex.EncodeToString(id[:]),
+		Signatures:  signatures,
+		Compression: "none",
+		PackedTrx:   hex.EncodeToString(packed),
+		Transaction: tx,
+	}, nil
+}
+
+// The "trx" field of a transaction receipt.
+// Either just a transaction id (for deferred transactions)
+// or a packed transaction.
+type ReceiptTrx struct {
+	ID     string
+	Packed *PackedTransaction
+}
+
+func (t ReceiptTrx) MarshalJSON() ([]byte, error) {
+	if t.Packed != nil {
+		return json.Marshal(t.Packed)
+	}
+	return json.Marshal(t.ID)
+}
+
+func (t *ReceiptTrx) UnmarshalJSON(b []byte) error {
+	if len(b) > 0 && b[0] == '"' {
+		t.Packed = nil
+		return json.Unmarshal(b, &t.ID)
+	}
+
+	var p PackedTransaction
+	err := json.Unmarshal(b, &p)
+	if err == nil {
+		t.ID = p.ID
+		t.Packed = &p
+	}
+	return err
+}
+
+// Transaction receipt format
+type TransactionReceipt struct {
+	Status        string     `json:"status"`
+	CPUUsageUS    uint32     `json:"cpu_usage_us"`
+	NetUsageWords uint32     `json:"net_usage_words"`
+	Trx           ReceiptTrx `json:"trx"`
+}
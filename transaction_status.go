@@ -0,0 +1,87 @@
+package leapapi
+
+import (
+	"fmt"
+	"time"
+)
+
+// TransactionState is the finality state of a transaction
+// as reported by get_transaction_status.
+type TransactionState int
+
+const (
+	// Transaction is not tracked by the node.
+	TransactionStateUnknown TransactionState = iota
+	// Transaction was applied locally but is not yet in a block.
+	TransactionStateLocallyApplied
+	// Transaction is included in a block.
+	TransactionStateInBlock
+	// Transaction is included in an irreversible block.
+	TransactionStateIrreversible
+	// Block containing the transaction was forked out.
+	TransactionStateForkedOut
+	// Transaction failed.
+	TransactionStateFailed
+)
+
+var transactionStateNames = []string{
+	"UNKNOWN",
+	"LOCALLY_APPLIED",
+	"IN_BLOCK",
+	"IRREVERSIBLE",
+	"FORKED_OUT",
+	"FAILED",
+}
+
+func (s TransactionState) String() string {
+	if s < 0 || int(s) >= len(transactionStateNames) {
+		return fmt.Sprintf("TransactionState(%d)", int(s))
+	}
+	return transactionStateNames[s]
+}
+
+// Reached returns true if s is target or a state that follows it.
+// A transaction that is irreversible is also in a block and so on.
+// Forked out and failed only reach themselves.
+func (s TransactionState) Reached(target TransactionState) bool {
+	if target <= TransactionStateIrreversible && s <= TransactionStateIrreversible {
+		return s >= target
+	}
+	return s == target
+}
+
+func (s TransactionState) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+func (s *TransactionState) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+
+	for i, name := range transactionStateNames {
+		if name == str {
+			*s = TransactionState(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("transaction state: unknown state %q", str)
+}
+
+// get_transaction_status format
+type TransactionStatus struct {
+	State                      TransactionState `json:"state"`
+	BlockNumber                uint32           `json:"block_number"`
+	BlockID                    string           `json:"block_id"`
+	BlockTimestamp             time.Time        `json:"block_timestamp" time_format:"2006-01-02T15:04:05.000"`
+	Expiration                 time.Time        `json:"expiration"`
+	HeadNumber                 uint32           `json:"head_number"`
+	HeadID                     string           `json:"head_id"`
+	HeadTimestamp              time.Time        `json:"head_timestamp" time_format:"2006-01-02T15:04:05.000"`
+	IrreversibleNumber         uint32           `json:"irreversible_number"`
+	IrreversibleID             string           `json:"irreversible_id"`
+	IrreversibleTimestamp      time.Time        `json:"irreversible_timestamp" time_format:"2006-01-02T15:04:05.000"`
+	EarliestTrackedBlockID     string           `json:"earliest_tracked_block_id"`
+	EarliestTrackedBlockNumber uint32           `json:"earliest_tracked_block_number"`
+}
@@ -0,0 +1,146 @@
+package leapapi
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionState_Json(t *testing.T) {
+	var state TransactionState
+	require.NoError(t, json.Unmarshal([]byte(`"IN_BLOCK"`), &state))
+	assert.Equal(t, TransactionStateInBlock, state)
+
+	data, err := json.Marshal(TransactionStateForkedOut)
+	require.NoError(t, err)
+	assert.Equal(t, `"FORKED_OUT"`, string(data))
+
+	err = json.Unmarshal([]byte(`"SOMETHING"`), &state)
+	assert.Contains(t, err.Error(), `transaction state: unknown state "SOMETHING"`)
+	assert.Equal(t, "TransactionState(10)", TransactionState(10).String())
+}
+
+func TestTransactionState_Reached(t *testing.T) {
+	tests := []struct {
+		state   TransactionState
+		target  TransactionState
+		reached bool
+	}{
+		{TransactionStateLocallyApplied, TransactionStateLocallyApplied, true},
+		{TransactionStateLocallyApplied, TransactionStateInBlock, false},
+		{TransactionStateIrreversible, TransactionStateInBlock, true},
+		{TransactionStateInBlock, TransactionStateIrreversible, false},
+		{TransactionStateUnknown, TransactionStateLocallyApplied, false},
+		{TransactionStateForkedOut, TransactionStateInBlock, false},
+		{TransactionStateFailed, TransactionStateIrreversible, false},
+		{TransactionStateIrreversible, TransactionStateForkedOut, false},
+		{TransactionStateForkedOut, TransactionStateForkedOut, true},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.reached, test.state.Reached(test.target), "%s -> %s", test.state, test.target)
+	}
+}
+
+func TestGetTransactionStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v1/chain/get_transaction_status", req.URL.String())
+
+		body, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"id": "`+testTrxID+`"}`, string(body))
+
+		payload := `{
+            "state": "IN_BLOCK",
+            "block_number": 100,
+            "block_id": "00000064a1b2c3d4e5f60718293a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a",
+            "block_timestamp": "2018-06-08T08:08:08.500",
+            "expiration": "2018-06-08T08:08:38",
+            "head_number": 102,
+            "head_id": "00000066a1b2c3d4e5f60718293a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a",
+            "head_timestamp": "2018-06-08T08:08:09.500",
+            "irreversible_number": 90,
+            "irreversible_id": "0000005aa1b2c3d4e5f60718293a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a",
+            "irreversible_timestamp": "2018-06-08T08:08:03.500",
+            "earliest_tracked_block_id": "00000001a1b2c3d4e5f60718293a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a",
+            "earliest_tracked_block_number": 1
+        }`
+		_, _ = res.Write([]byte(payload))
+	}))
+
+	client := New(srv.URL)
+	status, err := client.GetTransactionStatus(context.Background(), testTrxID)
+	require.NoError(t, err)
+
+	expected := TransactionStatus{
+		State:                      TransactionStateInBlock,
+		BlockNumber:                100,
+		BlockID:                    "00000064a1b2c3d4e5f60718293a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a",
+		BlockTimestamp:             time.Date(2018, 6, 8, 8, 8, 8, 500000000, time.UTC),
+		Expiration:                 time.Date(2018, 6, 8, 8, 8, 38, 0, time.UTC),
+		HeadNumber:                 102,
+		HeadID:                     "00000066a1b2c3d4e5f60718293a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a",
+		HeadTimestamp:              time.Date(2018, 6, 8, 8, 8, 9, 500000000, time.UTC),
+		IrreversibleNumber:         90,
+		IrreversibleID:             "0000005aa1b2c3d4e5f60718293a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a",
+		IrreversibleTimestamp:      time.Date(2018, 6, 8, 8, 8, 3, 500000000, time.UTC),
+		EarliestTrackedBlockID:     "00000001a1b2c3d4e5f60718293a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a",
+		EarliestTrackedBlockNumber: 1,
+	}
+	assert.Equal(t, expected, status)
+}
+
+func testTransactionStatusServer(states ...string) *httptest.Server {
+	calls := 0
+	return httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		state := states[len(states)-1]
+		if calls < len(states) {
+			state = states[calls]
+		}
+		calls++
+		_, _ = res.Write([]byte(`{"state": "` + state + `"}`))
+	}))
+}
+
+func TestWaitForTransaction(t *testing.T) {
+	transactionStatusPollInterval = time.Millisecond
+	defer func() { transactionStatusPollInterval = 500 * time.Millisecond }()
+
+	srv := testTransactionStatusServer("UNKNOWN", "LOCALLY_APPLIED", "FORKED_OUT", "IN_BLOCK", "IRREVERSIBLE")
+	client := New(srv.URL)
+
+	status, err := client.WaitForTransaction(context.Background(), testTrxID, TransactionStateIrreversible)
+	require.NoError(t, err)
+	assert.Equal(t, TransactionStateIrreversible, status.State)
+
+	// Failed
+	srv = testTransactionStatusServer("LOCALLY_APPLIED", "FAILED")
+	client = New(srv.URL)
+
+	status, err = client.WaitForTransaction(context.Background(), testTrxID, TransactionStateInBlock)
+	assert.EqualError(t, err, "transaction "+testTrxID+" failed")
+	assert.Equal(t, TransactionStateFailed, status.State)
+}
+
+func TestWaitForTransactionTimeout(t *testing.T) {
+	transactionStatusPollInterval = time.Millisecond
+	defer func() { transactionStatusPollInterval = 500 * time.Millisecond }()
+
+	srv := testTransactionStatusServer("IN_BLOCK")
+	client := New(srv.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	status, err := client.WaitForTransaction(ctx, testTrxID, TransactionStateIrreversible)
+	assert.Error(t, err)
+	assert.True(t, strings.HasSuffix(err.Error(), "deadline exceeded"), "Error was not deadline exceeded")
+	assert.Equal(t, TransactionStateInBlock, status.State)
+}
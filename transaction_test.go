@@ -0,0 +1,133 @@
+package leapapi
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testChainID    = "aca376f206b8fc25a6ed44dbdc66547c36c6c33e3a119ffbeaef943642f0e906"
+	testPackedTrx  = "68391a5b5d4ca1b2c3d4000000000100a6823403ea3055000000572d3ccdcd010000000000855c3400000000a8ed3232230000000000855c340000000000000e3d102700000000000004454f530000000002686900"
+	testTrxID      = "668b2b96ce802ca9599cc56042e5b5f4bb93763c30bc0693880f2fb85b573ebb"
+	testTrxDigest  = "24250ee96c29d2d6663dfc0b44541be8f10cb06685daa62ff910a8e5ed55d9d9"
+	testTransferTo = "0000000000855c340000000000000e3d102700000000000004454f5300000000026869"
+)
+
+func testTransaction(t *testing.T) Transaction {
+	info := Info{
+		HeadBlockID:             "0a3b4c60ffffffffffffffff0000000000000000000000000000000000000000",
+		HeadBlockTime:           time.Date(2018, 6, 8, 8, 7, 38, 0, time.UTC),
+		LastIrreversableBlockID: "0a3b4c5d11223344a1b2c3d40000000000000000000000000000000000000000",
+	}
+
+	tx := Transaction{
+		Actions: []Action{{
+			Account:       "eosio.token",
+			Name:          "transfer",
+			Authorization: []PermissionLevel{{Actor: "alice", Permission: "active"}},
+		}},
+	}
+
+	var abi ABI
+	require.NoError(t, json.Unmarshal([]byte(testTokenABI), &abi))
+	require.NoError(t, tx.Actions[0].SetData(&abi, map[string]interface{}{
+		"from":     "alice",
+		"to":       "bob",
+		"quantity": "1.0000 EOS",
+		"memo":     "hi",
+	}))
+	require.NoError(t, tx.SetTaPoS(info, 30*time.Second))
+	return tx
+}
+
+func TestTransaction_SetTaPoS(t *testing.T) {
+	tx := testTransaction(t)
+
+	assert.Equal(t, uint16(19549), tx.RefBlockNum)
+	assert.Equal(t, uint32(3569595041), tx.RefBlockPrefix)
+	assert.Equal(t, time.Date(2018, 6, 8, 8, 8, 8, 0, time.UTC), tx.Expiration)
+
+	// Fallback to head block.
+	err := tx.SetTaPoS(Info{HeadBlockID: "0a3b4c60ffffffff010000000000000000000000000000000000000000000000"}, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, uint16(0x4c60), tx.RefBlockNum)
+	assert.Equal(t, uint32(1), tx.RefBlockPrefix)
+
+	assert.EqualError(t, tx.SetReferenceBlock("0a3b"), `invalid block id "0a3b"`)
+}
+
+func TestTransaction_Pack(t *testing.T) {
+	tx := testTransaction(t)
+	assert.Equal(t, testTransferTo, tx.Actions[0].HexData)
+
+	packed, err := tx.Pack()
+	require.NoError(t, err)
+	assert.Equal(t, testPackedTrx, hex.EncodeToString(packed))
+
+	id, err := tx.ID()
+	require.NoError(t, err)
+	assert.Equal(t, testTrxID, id)
+
+	digest, err := tx.SigDigest(testChainID)
+	require.NoError(t, err)
+	assert.Equal(t, testTrxDigest, hex.EncodeToString(digest))
+
+	// Binary data can be provided directly.
+	data, _ := hex.DecodeString(testTransferTo)
+	tx.Actions[0].HexData = ""
+	tx.Actions[0].Data = data
+	packed, err = tx.Pack()
+	require.NoError(t, err)
+	assert.Equal(t, testPackedTrx, hex.EncodeToString(packed))
+
+	tx.Actions[0].Data = map[string]interface{}{}
+	_, err = tx.Pack()
+	assert.EqualError(t, err, "action eosio.token::transfer: data is not packed (use Action.SetData)")
+}
+
+func TestTransaction_Sign(t *testing.T) {
+	tx := testTransaction(t)
+
+	key, err := ParsePrivateKey(testWIF)
+	require.NoError(t, err)
+
+	sigs, err := tx.Sign(testChainID, key)
+	require.NoError(t, err)
+	require.Len(t, sigs, 1)
+
+	_, data, err := decodeSignatureString(sigs[0])
+	require.NoError(t, err)
+
+	digest, _ := hex.DecodeString(testTrxDigest)
+	pub, _, err := ecdsa.RecoverCompact(data, digest)
+	require.NoError(t, err)
+	assert.Equal(t, testPublicKey, encodePublicKeyString(KeyTypeK1, pub.SerializeCompressed()))
+
+	packed, err := NewPackedTransaction(tx, sigs)
+	require.NoError(t, err)
+	assert.Equal(t, testTrxID, packed.ID)
+	assert.Equal(t, "none", packed.Compression)
+	assert.Equal(t, testPackedTrx, packed.PackedTrx)
+	assert.Equal(t, sigs, packed.Signatures)
+}
+
+func TestTransaction_JsonEncodeEmpty(t *testing.T) {
+	data, err := json.Marshal(Transaction{Expiration: time.Date(2018, 6, 8, 8, 8, 8, 0, time.UTC)})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+        "expiration": "2018-06-08T08:08:08",
+        "ref_block_num": 0,
+        "ref_block_prefix": 0,
+        "max_net_usage_words": 0,
+        "max_cpu_usage_ms": 0,
+        "delay_sec": 0,
+        "context_free_actions": [],
+        "actions": [],
+        "transaction_extensions": []
+    }`, string(data))
+}
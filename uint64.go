@@ -0,0 +1,33 @@
+package leapapi
+
+import (
+	"strconv"
+)
+
+// Uint64 is an unsigned 64 bit integer that is decoded from either a
+// json number or a string (nodeos encodes large integers as strings).
+type Uint64 uint64
+
+func (v Uint64) MarshalJSON() ([]byte, error) {
+	return json.Marshal(uint64(v))
+}
+
+func (v *Uint64) UnmarshalJSON(b []byte) error {
+	var n uint64
+	if len(b) > 0 && b[0] == '"' {
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+
+		var err error
+		if n, err = strconv.ParseUint(s, 10, 64); err != nil {
+			return err
+		}
+	} else if err := json.Unmarshal(b, &n); err != nil {
+		return err
+	}
+
+	*v = Uint64(n)
+	return nil
+}
@@ -0,0 +1,156 @@
+package leapapi
+
+import (
+	"context"
+	"encoding/hex"
+	"net"
+)
+
+// Wallet is a client for the keosd wallet api ("/v1/wallet/*").
+type Wallet struct {
+	client *Client
+}
+
+// NewWallet creates a wallet client that connects to keosd over http.
+func NewWallet(url string) *Wallet {
+	return &Wallet{client: New(url)}
+}
+
+// NewUnixWallet creates a wallet client that connects to keosd
+// over a unix socket (for example ~/eosio-wallet/keosd.sock).
+func NewUnixWallet(path string) *Wallet {
+	c := New("http://localhost")
+
+	var d net.Dialer
+	c.client.SetDial(func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return d.DialContext(ctx, "unix", path)
+	})
+	return &Wallet{client: c}
+}
+
+// req sends string bodies as is, so they must be json encoded first.
+func jsonString(s string) []byte {
+	b, _ := json.Marshal(s)
+	return b
+}
+
+// Public/Private key pair
+type WalletKey struct {
+	PublicKey  string
+	PrivateKey string
+}
+
+//	Create - Fetches "/v1/wallet/create" from API
+//
+// Returns the password of the new wallet.
+// ---------------------------------------------------------
+func (w *Wallet) Create(ctx context.Context, name string) (password string, err error) {
+	err = w.client.send(ctx, "POST", "/v1/wallet/create", jsonString(name), &password)
+	return
+}
+
+//	Open - Fetches "/v1/wallet/open" from API
+//
+// ---------------------------------------------------------
+func (w *Wallet) Open(ctx context.Context, name string) error {
+	var res struct{}
+	return w.client.send(ctx, "POST", "/v1/wallet/open", jsonString(name), &res)
+}
+
+//	Lock - Fetches "/v1/wallet/lock" from API
+//
+// ---------------------------------------------------------
+func (w *Wallet) Lock(ctx context.Context, name string) error {
+	var res struct{}
+	return w.client.send(ctx, "POST", "/v1/wallet/lock", jsonString(name), &res)
+}
+
+//	LockAll - Fetches "/v1/wallet/lock_all" from API
+//
+// ---------------------------------------------------------
+func (w *Wallet) LockAll(ctx context.Context) error {
+	var res struct{}
+	return w.client.send(ctx, "POST", "/v1/wallet/lock_all", nil, &res)
+}
+
+//	Unlock - Fetches "/v1/wallet/unlock" from API
+//
+// ---------------------------------------------------------
+func (w *Wallet) Unlock(ctx context.Context, name string, password string) error {
+	var res struct{}
+	return w.client.send(ctx, "POST", "/v1/wallet/unlock", []string{name, password}, &res)
+}
+
+//	ImportKey - Fetches "/v1/wallet/import_key" from API
+//
+// ---------------------------------------------------------
+func (w *Wallet) ImportKey(ctx context.Context, name string, key string) error {
+	var res struct{}
+	return w.client.send(ctx, "POST", "/v1/wallet/import_key", []string{name, key}, &res)
+}
+
+//	ListWallets - Fetches "/v1/wallet/list_wallets" from API
+//
+// Unlocked wallets are marked with " *" after the name.
+// ---------------------------------------------------------
+func (w *Wallet) ListWallets(ctx context.Context) (wallets []string, err error) {
+	err = w.client.send(ctx, "POST", "/v1/wallet/list_wallets", nil, &wallets)
+	return
+}
+
+//	ListKeys - Fetches "/v1/wallet/list_keys" from API
+//
+// ---------------------------------------------------------
+func (w *Wallet) ListKeys(ctx context.Context, name string, password string) (keys []WalletKey, err error) {
+	var res [][2]string
+	err = w.client.send(ctx, "POST", "/v1/wallet/list_keys", []string{name, password}, &res)
+
+	for _, pair := range res {
+		keys = append(keys, WalletKey{PublicKey: pair[0], PrivateKey: pair[1]})
+	}
+	return
+}
+
+//	GetPublicKeys - Fetches "/v1/wallet/get_public_keys" from API
+//
+// Returns the public keys of all unlocked wallets.
+// ---------------------------------------------------------
+func (w *Wallet) GetPublicKeys(ctx context.Context) (keys []string, err error) {
+	err = w.client.send(ctx, "POST", "/v1/wallet/get_public_keys", nil, &keys)
+	return
+}
+
+//	SignTransaction - Fetches "/v1/wallet/sign_transaction" from API
+//
+// Returns the signatures of the keys.
+// ---------------------------------------------------------
+func (w *Wallet) SignTransaction(ctx context.Context, tx Transaction, keys []string, chainID string) (signatures []string, err error) {
+	// keosd does not have access to abis, so action data must be binary.
+	tx.ContextFreeActions = withHexData(tx.ContextFreeActions)
+	tx.Actions = withHexData(tx.Actions)
+
+	var res struct {
+		Signatures []string `json:"signatures"`
+	}
+	err = w.client.send(ctx, "POST", "/v1/wallet/sign_transaction", []interface{}{tx, keys, chainID}, &res)
+	signatures = res.Signatures
+	return
+}
+
+//	SignDigest - Fetches "/v1/wallet/sign_digest" from API
+//
+// ---------------------------------------------------------
+func (w *Wallet) SignDigest(ctx context.Context, digest []byte, key string) (signature string, err error) {
+	err = w.client.send(ctx, "POST", "/v1/wallet/sign_digest", []string{hex.EncodeToString(digest), key}, &signature)
+	return
+}
+
+// AvailableKeys implements SignatureProvider.
+func (w *Wallet) AvailableKeys(ctx context.Context) ([]string, error) {
+	return w.GetPublicKeys(ctx)
+}
+
+// Sign implements SignatureProvider.
+func (w *Wallet) Sign(ctx context.Context, chainID string, tx Transaction, keys []string) ([]string, error) {
+	return w.SignTransaction(ctx, tx, keys, chainID)
+}
@@ -0,0 +1,152 @@
+package leapapi
+
+import (
+	"context"
+	"encoding/hex"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Minimal keosd stand-in.
+func testWalletHandler(t *testing.T) http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+
+		expect := func(expected string) {
+			assert.JSONEq(t, expected, string(body), req.URL.String())
+		}
+
+		switch req.URL.String() {
+		case "/v1/wallet/create":
+			expect(`"default"`)
+			_, _ = res.Write([]byte(`"PW5KFWYKqvt63d4iNvedfDEPVZL227D3RQ1zpVFzuUwhMAJmRAYyX"`))
+		case "/v1/wallet/open", "/v1/wallet/lock":
+			expect(`"default"`)
+			_, _ = res.Write([]byte(`{}`))
+		case "/v1/wallet/lock_all":
+			assert.Empty(t, body)
+			_, _ = res.Write([]byte(`{}`))
+		case "/v1/wallet/unlock":
+			if string(body) != `["default","secret"]` {
+				res.WriteHeader(500)
+				_, _ = res.Write([]byte(`{
+                    "code": 500,
+                    "message": "Internal Service Error",
+                    "error": {"code": 3120005, "name": "wallet_invalid_password_exception", "what": "Invalid wallet password", "details": []}
+                }`))
+				return
+			}
+			_, _ = res.Write([]byte(`{}`))
+		case "/v1/wallet/import_key":
+			expect(`["default", "` + testWIF + `"]`)
+			_, _ = res.Write([]byte(`{}`))
+		case "/v1/wallet/list_wallets":
+			_, _ = res.Write([]byte(`["default *", "other"]`))
+		case "/v1/wallet/list_keys":
+			expect(`["default", "secret"]`)
+			_, _ = res.Write([]byte(`[["` + testPublicKey + `", "` + testWIF + `"]]`))
+		case "/v1/wallet/get_public_keys":
+			_, _ = res.Write([]byte(`["` + testPublicKey + `"]`))
+		case "/v1/wallet/sign_transaction":
+			expect(`[
+                {
+                    "expiration": "2018-06-08T08:08:08",
+                    "ref_block_num": 19549,
+                    "ref_block_prefix": 3569595041,
+                    "max_net_usage_words": 0,
+                    "max_cpu_usage_ms": 0,
+                    "delay_sec": 0,
+                    "context_free_actions": [],
+                    "actions": [{
+                        "account": "eosio.token",
+                        "name": "transfer",
+                        "authorization": [{"actor": "alice", "permission": "active"}],
+                        "data": "` + testTransferTo + `"
+                    }],
+                    "transaction_extensions": []
+                },
+                ["` + testPublicKey + `"],
+                "` + testChainID + `"
+            ]`)
+			_, _ = res.Write([]byte(`{"expiration": "2018-06-08T08:08:08", "actions": [], "signatures": ["SIG_K1_test"]}`))
+		case "/v1/wallet/sign_digest":
+			expect(`["` + testTrxDigest + `", "` + testPublicKey + `"]`)
+			_, _ = res.Write([]byte(`"SIG_K1_digest"`))
+		default:
+			t.Errorf("unexpected request %s", req.URL)
+		}
+	}
+}
+
+func testWallet(t *testing.T, w *Wallet) {
+	ctx := context.Background()
+
+	password, err := w.Create(ctx, "default")
+	require.NoError(t, err)
+	assert.Equal(t, "PW5KFWYKqvt63d4iNvedfDEPVZL227D3RQ1zpVFzuUwhMAJmRAYyX", password)
+
+	require.NoError(t, w.Open(ctx, "default"))
+	require.NoError(t, w.Unlock(ctx, "default", "secret"))
+	require.NoError(t, w.ImportKey(ctx, "default", testWIF))
+	require.NoError(t, w.Lock(ctx, "default"))
+	require.NoError(t, w.LockAll(ctx))
+
+	err = w.Unlock(ctx, "default", "wrong")
+	require.EqualError(t, err, "500 Internal Service Error")
+	assert.Equal(t, "wallet_invalid_password_exception", err.(APIError).Err.Name)
+
+	wallets, err := w.ListWallets(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"default *", "other"}, wallets)
+
+	keys, err := w.ListKeys(ctx, "default", "secret")
+	require.NoError(t, err)
+	assert.Equal(t, []WalletKey{{PublicKey: testPublicKey, PrivateKey: testWIF}}, keys)
+
+	pub, err := w.GetPublicKeys(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{testPublicKey}, pub)
+
+	digest, _ := hex.DecodeString(testTrxDigest)
+	sig, err := w.SignDigest(ctx, digest, testPublicKey)
+	require.NoError(t, err)
+	assert.Equal(t, "SIG_K1_digest", sig)
+
+	// SignatureProvider
+	var provider SignatureProvider = w
+
+	available, err := provider.AvailableKeys(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{testPublicKey}, available)
+
+	sigs, err := provider.Sign(ctx, testChainID, testTransaction(t), available)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"SIG_K1_test"}, sigs)
+}
+
+func TestWallet(t *testing.T) {
+	srv := httptest.NewServer(testWalletHandler(t))
+	defer srv.Close()
+
+	testWallet(t, NewWallet(srv.URL))
+}
+
+func TestWalletUnixSocket(t *testing.T) {
+	l, err := net.Listen("unix", filepath.Join(t.TempDir(), "keosd.sock"))
+	require.NoError(t, err)
+
+	srv := httptest.NewUnstartedServer(testWalletHandler(t))
+	srv.Listener = l
+	srv.Start()
+	defer srv.Close()
+
+	testWallet(t, NewUnixWallet(l.Addr().String()))
+}